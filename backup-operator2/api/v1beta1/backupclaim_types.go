@@ -18,14 +18,27 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
 	StatusReconciling                = "Reconciling"
 	StatusFailedToResolveSource      = "Failed to resolve source"
 	StatusFailedToResolveDestination = "Failed to resolve destination"
+	StatusDeniedByPolicy             = "Denied by policy"
+	StatusTimedOut                   = "Timed out"
 	StatusReady                      = "Ready"
+	StatusDegraded                   = "Degraded"
+)
+
+// What to do when a pod the backup was delivered to is recreated or fails
+const (
+	// Deliver the backup again
+	RecoveryPolicyRestore = "Restore"
+	// Mark the claim Degraded and leave the pods as they are
+	RecoveryPolicyDegrade = "Degrade"
 )
 
 // BackupClaimSpec defines the desired state of BackupClaim
@@ -37,6 +50,13 @@ type BackupClaimSpec struct {
 	Source BackupClaimSourceSpec `json:"source,omitempty"`
 	// destination for the backup
 	Destination BackupClaimDestinationSpec `json:"destination,omitempty"`
+	// how long each step of the claim may take. Steps have no timeout by
+	// default.
+	Timeouts BackupClaimTimeoutsSpec `json:"timeouts,omitempty"`
+	// what to do when a pod the backup was delivered to is recreated or
+	// fails, losing the backup. Defaults to Restore.
+	// +kubebuilder:validation:Enum=Restore;Degrade
+	RecoveryPolicy string `json:"recoveryPolicy,omitempty"`
 }
 
 // BackupClaimStatus defines the observed state of BackupClaim
@@ -54,6 +74,66 @@ type BackupClaimStatus struct {
 
 	// When was this backup claim resolved
 	ResolvedAt *metav1.Time `json:"resolvedAt,omitempty"`
+
+	// Volume holding the backup, for PVC destinations
+	Volume *BackupClaimVolumeStatus `json:"volume,omitempty"`
+
+	// Name of the pod created for the new pod destination
+	PodName string `json:"podName,omitempty"`
+
+	// How to connect to the database of the new pod destination
+	Connection *BackupClaimConnectionStatus `json:"connection,omitempty"`
+
+	// Pods the backup was delivered to
+	DeliveredTo []BackupClaimDeliveredPod `json:"deliveredTo,omitempty"`
+
+	// When the backup was last lost with its pods. The podReady timeout
+	// counts from then when it is restored again.
+	LostAt *metav1.Time `json:"lostAt,omitempty"`
+}
+
+type BackupClaimDeliveredPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// UID of the pod, telling it apart from a pod recreated with the same
+	// name
+	UID types.UID `json:"uid"`
+
+	// Restart count of each container of the pod when the backup was
+	// delivered. Restarted containers lost what the backup left in them.
+	RestartCounts map[string]int32 `json:"restartCounts,omitempty"`
+}
+
+type BackupClaimConnectionStatus struct {
+	// Service in front of the pod
+	ServiceName string `json:"serviceName"`
+
+	// Secret holding the host, port, database and credentials of the
+	// database, meant to be consumed with envFrom
+	SecretName string `json:"secretName"`
+}
+
+type BackupClaimVolumeStatus struct {
+	// Name of the PersistentVolumeClaim holding the backup
+	ClaimName string `json:"claimName"`
+
+	// Name of the PersistentVolume bound to the claim
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// Path of the backup in the volume
+	Path string `json:"path"`
+
+	// Version of the source found in the manifest written next to the
+	// backup in the volume
+	Version string `json:"version,omitempty"`
+
+	// VolumeSnapshot taken of the volume once the backup was written
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// VolumeSnapshot the volume was cloned from, instead of transferring
+	// the backup
+	ClonedFrom string `json:"clonedFrom,omitempty"`
 }
 
 // SOURCE SPEC
@@ -61,11 +141,44 @@ type BackupClaimStatus struct {
 
 type BackupClaimSourceSpec struct {
 	S3 BackupClaimS3SourceSpec `json:"s3,omitempty"`
+
+	// Decrypt the backup while it is transferred, for backups encrypted
+	// before upload
+	Decryption *BackupClaimDecryptionSpec `json:"decryption,omitempty"`
+}
+
+type BackupClaimDecryptionSpec struct {
+	// Tool the backup was encrypted with
+	// +kubebuilder:validation:Enum=age;gpg;openssl
+	Type string `json:"type"`
+
+	// Secret key holding the age identities or the gpg private key
+	KeySecretRef *corev1.SecretKeySelector `json:"keySecretRef,omitempty"`
+
+	// Secret key holding the age or gpg passphrase, the passphrase of the gpg
+	// private key or the openssl password
+	PassphraseSecretRef *corev1.SecretKeySelector `json:"passphraseSecretRef,omitempty"`
 }
 
 type BackupClaimS3SourceSpec struct {
 	BucketName string `json:"bucketName,required"`
 	Key        string `json:"key,required"`
+
+	// IAM role to assume on top of the operator credentials, for buckets
+	// living in another account
+	AssumeRole *BackupClaimS3AssumeRoleSpec `json:"assumeRole,omitempty"`
+
+	// Secret key holding the customer provided key of an object encrypted
+	// with SSE-C. Objects encrypted with SSE-S3 or SSE-KMS need no key.
+	SSECustomerKeySecretRef *corev1.SecretKeySelector `json:"sseCustomerKeySecretRef,omitempty"`
+}
+
+type BackupClaimS3AssumeRoleSpec struct {
+	// ARN of the role to assume
+	RoleARN string `json:"roleArn"`
+
+	// External ID expected by the trust policy of the role
+	ExternalID string `json:"externalId,omitempty"`
 }
 
 // DESTINATION SPECS
@@ -74,11 +187,64 @@ type BackupClaimS3SourceSpec struct {
 type BackupClaimDestinationSpec struct {
 	Pod         BackupClaimNewPodDestinationSpec      `json:"pod,omitempty"`
 	ExistingPod BackupClaimExistingPodDestinationSpec `json:"existingPod,omitempty"`
+	PVC         BackupClaimPVCDestinationSpec         `json:"pvc,omitempty"`
 }
 
+// Which of the pods of a workload or selector receive the backup
+const (
+	// The first running and ready pod, by ordinal or name
+	ExistingPodPolicyFirstReady = "FirstReady"
+	// Every pod, once they are all running
+	ExistingPodPolicyAll = "All"
+	// The pod of the given ordinal of a StatefulSet, or the pod at the
+	// given index of the pods sorted by name
+	ExistingPodPolicyOrdinal = "Ordinal"
+)
+
 type BackupClaimExistingPodDestinationSpec struct {
 	Namespace string `json:"namespace,omitempty"`
 	Name      string `json:"name,omitempty"`
+
+	// Workload whose pods receive the backup, instead of a pod name. The
+	// pods are resolved on every reconcile.
+	WorkloadRef *BackupClaimWorkloadReference `json:"workloadRef,omitempty"`
+
+	// Labels of the pods receiving the backup, instead of a pod name
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Container receiving the backup. Defaults to the first container.
+	Container string `json:"container,omitempty"`
+
+	// Name of a volume mount of the container receiving the backup. Relative
+	// paths and directories are relative to its mount path.
+	VolumeMount string `json:"volumeMount,omitempty"`
+
+	// Directory receiving the backup under its key. Defaults to
+	// /tmp/<bucket name>, or the mount path of the volume mount.
+	Directory string `json:"directory,omitempty"`
+
+	// Path of the backup in the container, instead of a directory
+	Path string `json:"path,omitempty"`
+
+	// Which of the pods of the workload or selector receive the backup.
+	// Defaults to FirstReady.
+	// +kubebuilder:validation:Enum=FirstReady;All;Ordinal
+	Policy string `json:"policy,omitempty"`
+
+	// Ordinal of the pod receiving the backup, with the Ordinal policy
+	Ordinal *int32 `json:"ordinal,omitempty"`
+
+	// Database engine of the container, probed until it accepts connections
+	// before delivering the backup. Without it, the container only has to
+	// be ready.
+	// +kubebuilder:validation:Enum=mysql;postgres;mongodb
+	Engine string `json:"engine,omitempty"`
+}
+
+type BackupClaimWorkloadReference struct {
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;ReplicaSet
+	Kind string `json:"kind"`
+	Name string `json:"name"`
 }
 
 type BackupClaimNewPodDestinationSpec struct {
@@ -88,6 +254,85 @@ type BackupClaimNewPodDestinationSpec struct {
 
 	// Resources requested
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Overrides of the pod, merged onto the defaults of the engine by
+	// strategic merge: lists such as env, volumes or imagePullSecrets are
+	// merged by name.
+	Template *BackupClaimPodTemplateSpec `json:"template,omitempty"`
+}
+
+// BackupClaimPodTemplateSpec holds the fields of a pod template the new pod
+// destination can override. Container fields apply to the database
+// container.
+type BackupClaimPodTemplateSpec struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Image of the database container
+	Image string `json:"image,omitempty"`
+
+	Env          []corev1.EnvVar        `json:"env,omitempty"`
+	EnvFrom      []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	VolumeMounts []corev1.VolumeMount   `json:"volumeMounts,omitempty"`
+
+	Volumes            []corev1.Volume               `json:"volumes,omitempty"`
+	NodeSelector       map[string]string             `json:"nodeSelector,omitempty"`
+	Tolerations        []corev1.Toleration           `json:"tolerations,omitempty"`
+	Affinity           *corev1.Affinity              `json:"affinity,omitempty"`
+	SecurityContext    *corev1.PodSecurityContext    `json:"securityContext,omitempty"`
+	ImagePullSecrets   []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	ServiceAccountName string                        `json:"serviceAccountName,omitempty"`
+}
+
+type BackupClaimPVCDestinationSpec struct {
+	// Name of the PersistentVolumeClaim receiving the backup. It is created
+	// if it does not exist. Claims not created by the backup claim are
+	// refused, the backup would overwrite their data.
+	Name string `json:"name,omitempty"`
+
+	// Storage class of the created claim. Defaults to the default storage
+	// class of the cluster.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size of the created claim. Defaults to the size of the backup times
+	// DecompressionFactor, and at least 1Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// How much room the backup needs in the volume, relative to its size.
+	// Compressed backups are decompressed in the volume. Defaults to 1 for
+	// uncompressed backups and 5 for compressed ones.
+	// +kubebuilder:validation:Minimum=1
+	DecompressionFactor int32 `json:"decompressionFactor,omitempty"`
+
+	// Image of the pod writing the backup in the volume. It needs tee, xz
+	// and gzip. Defaults to debian:stable-slim.
+	LoaderImage string `json:"loaderImage,omitempty"`
+
+	// Take a CSI VolumeSnapshot of the volume once the backup is written.
+	// Later claims of the same version of the backup in the namespace clone
+	// their volume from the snapshot instead of transferring the backup.
+	Snapshot *BackupClaimSnapshotSpec `json:"snapshot,omitempty"`
+}
+
+type BackupClaimSnapshotSpec struct {
+	// VolumeSnapshotClass of the snapshot. Defaults to the default class of
+	// the CSI driver.
+	ClassName *string `json:"className,omitempty"`
+}
+
+// TIMEOUTS SPEC
+//
+
+type BackupClaimTimeoutsSpec struct {
+	// How long to wait for the destination pod to be running, since the
+	// claim was created
+	PodReady *metav1.Duration `json:"podReady,omitempty"`
+
+	// How long the transfer of the backup to the destination pod may take
+	Transfer *metav1.Duration `json:"transfer,omitempty"`
+
+	// How long the import of the backup in the database may take
+	Import *metav1.Duration `json:"import,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,84 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupSourcePolicySpec defines which sources and destinations the
+// BackupClaims of a set of namespaces may use
+type BackupSourcePolicySpec struct {
+	// Namespaces this policy applies to
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Select the namespaces this policy applies to by label
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// S3 buckets claims may read from
+	S3 []BackupSourcePolicyS3Spec `json:"s3,omitempty"`
+
+	// Destinations claims may deliver to. Every destination is allowed when
+	// not set
+	Destinations *BackupSourcePolicyDestinationsSpec `json:"destinations,omitempty"`
+}
+
+type BackupSourcePolicyS3Spec struct {
+	BucketName string `json:"bucketName"`
+
+	// Key prefixes claims may read in the bucket. Every key is allowed when
+	// empty
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+type BackupSourcePolicyDestinationsSpec struct {
+	// Allow claims to create a new pod
+	Pod bool `json:"pod,omitempty"`
+
+	// Allow claims to load the backup in a PersistentVolumeClaim of their
+	// namespace
+	PVC bool `json:"pvc,omitempty"`
+
+	// Namespaces in which claims may deliver to an existing pod
+	ExistingPodNamespaces []string `json:"existingPodNamespaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// BackupSourcePolicy restricts the sources and destinations BackupClaims may
+// use. When at least one policy exists, a claim is only accepted if a policy
+// applying to its namespace allows it.
+type BackupSourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupSourcePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BackupSourcePolicyList contains a list of BackupSourcePolicy
+type BackupSourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupSourcePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSourcePolicy{}, &BackupSourcePolicyList{})
+}
@@ -1,5 +1,4 @@
 //go:build !ignore_autogenerated
-// +build !ignore_autogenerated
 
 /*
 Copyright 2021.
@@ -22,6 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -52,11 +53,74 @@ func (in *BackupClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimConnectionStatus) DeepCopyInto(out *BackupClaimConnectionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimConnectionStatus.
+func (in *BackupClaimConnectionStatus) DeepCopy() *BackupClaimConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimDecryptionSpec) DeepCopyInto(out *BackupClaimDecryptionSpec) {
+	*out = *in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PassphraseSecretRef != nil {
+		in, out := &in.PassphraseSecretRef, &out.PassphraseSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimDecryptionSpec.
+func (in *BackupClaimDecryptionSpec) DeepCopy() *BackupClaimDecryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimDecryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimDeliveredPod) DeepCopyInto(out *BackupClaimDeliveredPod) {
+	*out = *in
+	if in.RestartCounts != nil {
+		in, out := &in.RestartCounts, &out.RestartCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimDeliveredPod.
+func (in *BackupClaimDeliveredPod) DeepCopy() *BackupClaimDeliveredPod {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimDeliveredPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupClaimDestinationSpec) DeepCopyInto(out *BackupClaimDestinationSpec) {
 	*out = *in
 	in.Pod.DeepCopyInto(&out.Pod)
-	out.ExistingPod = in.ExistingPod
+	in.ExistingPod.DeepCopyInto(&out.ExistingPod)
+	in.PVC.DeepCopyInto(&out.PVC)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimDestinationSpec.
@@ -72,6 +136,21 @@ func (in *BackupClaimDestinationSpec) DeepCopy() *BackupClaimDestinationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupClaimExistingPodDestinationSpec) DeepCopyInto(out *BackupClaimExistingPodDestinationSpec) {
 	*out = *in
+	if in.WorkloadRef != nil {
+		in, out := &in.WorkloadRef, &out.WorkloadRef
+		*out = new(BackupClaimWorkloadReference)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ordinal != nil {
+		in, out := &in.Ordinal, &out.Ordinal
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimExistingPodDestinationSpec.
@@ -120,6 +199,11 @@ func (in *BackupClaimList) DeepCopyObject() runtime.Object {
 func (in *BackupClaimNewPodDestinationSpec) DeepCopyInto(out *BackupClaimNewPodDestinationSpec) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(BackupClaimPodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimNewPodDestinationSpec.
@@ -132,9 +216,150 @@ func (in *BackupClaimNewPodDestinationSpec) DeepCopy() *BackupClaimNewPodDestina
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimPVCDestinationSpec) DeepCopyInto(out *BackupClaimPVCDestinationSpec) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(BackupClaimSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimPVCDestinationSpec.
+func (in *BackupClaimPVCDestinationSpec) DeepCopy() *BackupClaimPVCDestinationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimPVCDestinationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimPodTemplateSpec) DeepCopyInto(out *BackupClaimPodTemplateSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimPodTemplateSpec.
+func (in *BackupClaimPodTemplateSpec) DeepCopy() *BackupClaimPodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimPodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimS3AssumeRoleSpec) DeepCopyInto(out *BackupClaimS3AssumeRoleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimS3AssumeRoleSpec.
+func (in *BackupClaimS3AssumeRoleSpec) DeepCopy() *BackupClaimS3AssumeRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimS3AssumeRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupClaimS3SourceSpec) DeepCopyInto(out *BackupClaimS3SourceSpec) {
 	*out = *in
+	if in.AssumeRole != nil {
+		in, out := &in.AssumeRole, &out.AssumeRole
+		*out = new(BackupClaimS3AssumeRoleSpec)
+		**out = **in
+	}
+	if in.SSECustomerKeySecretRef != nil {
+		in, out := &in.SSECustomerKeySecretRef, &out.SSECustomerKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimS3SourceSpec.
@@ -147,10 +372,35 @@ func (in *BackupClaimS3SourceSpec) DeepCopy() *BackupClaimS3SourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimSnapshotSpec) DeepCopyInto(out *BackupClaimSnapshotSpec) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimSnapshotSpec.
+func (in *BackupClaimSnapshotSpec) DeepCopy() *BackupClaimSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupClaimSourceSpec) DeepCopyInto(out *BackupClaimSourceSpec) {
 	*out = *in
-	out.S3 = in.S3
+	in.S3.DeepCopyInto(&out.S3)
+	if in.Decryption != nil {
+		in, out := &in.Decryption, &out.Decryption
+		*out = new(BackupClaimDecryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimSourceSpec.
@@ -166,8 +416,9 @@ func (in *BackupClaimSourceSpec) DeepCopy() *BackupClaimSourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupClaimSpec) DeepCopyInto(out *BackupClaimSpec) {
 	*out = *in
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
 	in.Destination.DeepCopyInto(&out.Destination)
+	in.Timeouts.DeepCopyInto(&out.Timeouts)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimSpec.
@@ -191,6 +442,27 @@ func (in *BackupClaimStatus) DeepCopyInto(out *BackupClaimStatus) {
 		in, out := &in.ResolvedAt, &out.ResolvedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(BackupClaimVolumeStatus)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(BackupClaimConnectionStatus)
+		**out = **in
+	}
+	if in.DeliveredTo != nil {
+		in, out := &in.DeliveredTo, &out.DeliveredTo
+		*out = make([]BackupClaimDeliveredPod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LostAt != nil {
+		in, out := &in.LostAt, &out.LostAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimStatus.
@@ -202,3 +474,198 @@ func (in *BackupClaimStatus) DeepCopy() *BackupClaimStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimTimeoutsSpec) DeepCopyInto(out *BackupClaimTimeoutsSpec) {
+	*out = *in
+	if in.PodReady != nil {
+		in, out := &in.PodReady, &out.PodReady
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Transfer != nil {
+		in, out := &in.Transfer, &out.Transfer
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimTimeoutsSpec.
+func (in *BackupClaimTimeoutsSpec) DeepCopy() *BackupClaimTimeoutsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimTimeoutsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimVolumeStatus) DeepCopyInto(out *BackupClaimVolumeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimVolumeStatus.
+func (in *BackupClaimVolumeStatus) DeepCopy() *BackupClaimVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupClaimWorkloadReference) DeepCopyInto(out *BackupClaimWorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupClaimWorkloadReference.
+func (in *BackupClaimWorkloadReference) DeepCopy() *BackupClaimWorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupClaimWorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSourcePolicy) DeepCopyInto(out *BackupSourcePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSourcePolicy.
+func (in *BackupSourcePolicy) DeepCopy() *BackupSourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupSourcePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSourcePolicyDestinationsSpec) DeepCopyInto(out *BackupSourcePolicyDestinationsSpec) {
+	*out = *in
+	if in.ExistingPodNamespaces != nil {
+		in, out := &in.ExistingPodNamespaces, &out.ExistingPodNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSourcePolicyDestinationsSpec.
+func (in *BackupSourcePolicyDestinationsSpec) DeepCopy() *BackupSourcePolicyDestinationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSourcePolicyDestinationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSourcePolicyList) DeepCopyInto(out *BackupSourcePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupSourcePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSourcePolicyList.
+func (in *BackupSourcePolicyList) DeepCopy() *BackupSourcePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSourcePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupSourcePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSourcePolicyS3Spec) DeepCopyInto(out *BackupSourcePolicyS3Spec) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSourcePolicyS3Spec.
+func (in *BackupSourcePolicyS3Spec) DeepCopy() *BackupSourcePolicyS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSourcePolicyS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSourcePolicySpec) DeepCopyInto(out *BackupSourcePolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = make([]BackupSourcePolicyS3Spec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = new(BackupSourcePolicyDestinationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSourcePolicySpec.
+func (in *BackupSourcePolicySpec) DeepCopy() *BackupSourcePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSourcePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
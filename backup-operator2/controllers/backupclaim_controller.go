@@ -18,37 +18,57 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/go-logr/logr"
 	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/engine"
 	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/policy"
 	"github.com/nvanheuverzwijn/backup-operator/pkg/source"
-	"github.com/go-logr/logr"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"path/filepath"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"strings"
+	"sync"
 	"time"
 )
 
 var (
 	jobOwnerKey = ".metadata.controller"
 	apiGVStr    = backupsv1beta1.GroupVersion.String()
-	logger      logr.Logger
-	backupClaim backupsv1beta1.BackupClaim
 )
 
+// claimContext holds the claim being reconciled and its logger. Claims are
+// reconciled concurrently, so it is passed to every step instead of being
+// kept on the reconciler.
+type claimContext struct {
+	claim  *backupsv1beta1.BackupClaim
+	logger logr.Logger
+}
+
 // BackupClaimReconciler reconciles a BackupClaim object
 type BackupClaimReconciler struct {
 	RestConfig *rest.Config
@@ -56,6 +76,34 @@ type BackupClaimReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	AwsSession *session.Session
+	AwsRoles   *source.AssumeRoleCache
+
+	// Reads from the API server the objects the cache may not have seen yet.
+	// Defaults to the API reader of the manager.
+	APIReader client.Reader
+
+	// Role and projected service account token used to get the base AWS
+	// credentials through web identity (IRSA). Leave empty to use the default
+	// credential chain.
+	AwsWebIdentityRoleARN   string
+	AwsWebIdentityTokenFile string
+
+	// Returns the S3 client used to read the sources. Defaults to a client
+	// using the AWS session, or the assumed role if roleARN is not empty.
+	NewS3Client func(roleARN, externalID, sessionName string) s3iface.S3API
+	// Returns the executor of the destination containers. Defaults to
+	// executors using the exec subresource of the pods.
+	NewExecutor pod.ExecutorFactory
+
+	// Number of claims reconciled at the same time. Defaults to 1.
+	MaxConcurrentReconciles int
+
+	// Records the events of the claims, shown by `kubectl describe`
+	Recorder     record.EventRecorder
+	throttle     *eventThrottle
+	throttleOnce sync.Once
+	// When the glacier restores were requested, by claim UID
+	glacierRequests sync.Map
 }
 
 type BackupClaimReconcilers struct {
@@ -69,6 +117,16 @@ type BackupClaimReconcilers struct {
 //+kubebuilder:rbac:groups=backups.nvanheuverzwijn.io,resources=backupclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=backups.nvanheuverzwijn.io,resources=backupclaims/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=backups.nvanheuverzwijn.io,resources=backupclaims/finalizers,verbs=update
+//+kubebuilder:rbac:groups=backups.nvanheuverzwijn.io,resources=backupsourcepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;replicasets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -80,254 +138,682 @@ type BackupClaimReconcilers struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.10.0/pkg/reconcile
 func (r *BackupClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger = log.FromContext(ctx)
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile", trace.WithAttributes(
+		tracing.ClaimNamespace.String(req.Namespace),
+		tracing.ClaimName.String(req.Name),
+	))
+	result, err := r.reconcile(ctx, req)
+	tracing.End(span, err)
+	return result, err
+}
 
-	if err := r.Get(ctx, req.NamespacedName, &backupClaim); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+func (r *BackupClaimReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cc := &claimContext{
+		claim:  &backupsv1beta1.BackupClaim{},
+		logger: log.FromContext(ctx),
+	}
+	if err := r.Get(ctx, req.NamespacedName, cc.claim); apierrors.IsNotFound(err) {
+		// Deleted claims have no more events
+		r.eventThrottle().Forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(tracing.ClaimUID.String(string(cc.claim.UID)))
+
+	// Make sure the claim is allowed before touching any source or destination.
+	// Denied claims are reconciled again when the policies change.
+	var denied *policy.DeniedError
+	if err := policy.Check(ctx, r, cc.claim); errors.As(err, &denied) {
+		cc.logger.Info("Backup claim denied by policy", "reason", denied.Error())
+		r.recordFailure(cc.claim, EventReasonDeniedByPolicy, "Denied by policy: %s", denied.Error())
+		cc.claim.Status.Status = backupsv1beta1.StatusDeniedByPolicy
+		cc.claim.Status.Error = denied.Error()
+		_ = r.Status().Update(ctx, cc.claim)
+		r.eventThrottle().Forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// Handle the destination creation
 	var childPod *corev1.Pod
+	var existingPods []corev1.Pod
 	var s3file *source.S3File
 	var err error
 	var wait bool
 
 	// Handle Pod Destination
-	if backupClaim.Spec.Destination.Pod.NamePrefix != "" {
-		childPod, wait, err = r.HandleDestinationPod(ctx, req)
+	if cc.claim.Spec.Destination.Pod.NamePrefix != "" {
+		childPod, wait, err = r.HandleDestinationPod(ctx, cc)
 		// If there's an error, treat it
-		if err != nil {
-			logger.Error(err, "Could not check pod status")
-			backupClaim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
-			backupClaim.Status.Error = err.Error()
-			_ = r.Status().Update(ctx, &backupClaim)
+		var lost *lostError
+		if errors.As(err, &lost) {
+			r.degraded(ctx, cc, err)
+			return ctrl.Result{}, nil
+		} else if err != nil {
+			cc.logger.Error(err, "Could not check pod status")
+			r.recordFailure(cc.claim, EventReasonFailedToResolveDestination, "Could not resolve destination pod: %s", err.Error())
+			cc.claim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
+			cc.claim.Status.Error = err.Error()
+			_ = r.Status().Update(ctx, cc.claim)
 			return ctrl.Result{}, err
 			// If we have to wait, return
 		} else if wait {
-			logger.Info("Pod is not ready")
-			backupClaim.Status.Status = backupsv1beta1.StatusReconciling
-			backupClaim.Status.Error = ""
-			_ = r.Status().Update(ctx, &backupClaim)
-			return ctrl.Result{}, nil
+			remaining, err := podReadyTimeout(cc.claim, time.Now())
+			if err != nil {
+				r.timedOut(ctx, cc, err)
+				return ctrl.Result{}, nil
+			}
+			cc.logger.Info("Pod is not ready")
+			r.recordRepeatedEvent(cc.claim, EventReasonWaitingForPod, "Waiting for the destination pod to be ready")
+			cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+			cc.claim.Status.Error = ""
+			_ = r.Status().Update(ctx, cc.claim)
+			// Pod updates trigger a reconcile, only come back for the timeout
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		cc.logger.Info("Pod is ready")
+		if cc.claim.Status.Status != backupsv1beta1.StatusReady {
+			r.recordRepeatedEvent(cc.claim, EventReasonPodReady, "Destination pod %s/%s is running", childPod.Namespace, childPod.Name)
 		}
-		logger.Info("Pod is ready")
 	}
 
 	// Handle ExistingPod Destination
-	if backupClaim.Spec.Destination.ExistingPod.Namespace != "" {
-		childPod, wait, err = r.HandleDestinationExistingPod(ctx, req)
+	if cc.claim.Spec.Destination.ExistingPod.Namespace != "" {
+		existingPods, wait, err = r.HandleDestinationExistingPod(ctx, cc)
 		// If there's an error, treat it
-		if err != nil {
-			logger.Error(err, "Could not check existing pod status")
-			backupClaim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
-			backupClaim.Status.Error = err.Error()
-			_ = r.Status().Update(ctx, &backupClaim)
+		var lost *lostError
+		if errors.As(err, &lost) {
+			r.degraded(ctx, cc, err)
+			return ctrl.Result{}, nil
+		} else if err != nil {
+			cc.logger.Error(err, "Could not check existing pod status")
+			r.recordFailure(cc.claim, EventReasonFailedToResolveDestination, "Could not resolve existing pod: %s", err.Error())
+			cc.claim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
+			cc.claim.Status.Error = err.Error()
+			_ = r.Status().Update(ctx, cc.claim)
 			return ctrl.Result{}, err
 			// If we have to wait, return
 		} else if wait {
-			logger.Info("Pod is not ready")
-			backupClaim.Status.Status = backupsv1beta1.StatusReconciling
-			backupClaim.Status.Error = ""
-			_ = r.Status().Update(ctx, &backupClaim)
+			if _, err := podReadyTimeout(cc.claim, time.Now()); err != nil {
+				r.timedOut(ctx, cc, err)
+				return ctrl.Result{}, nil
+			}
+			cc.logger.Info("Pod is not ready")
+			r.recordRepeatedEvent(cc.claim, EventReasonWaitingForPod, "Waiting for the destination pod to be ready")
+			cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+			cc.claim.Status.Error = ""
+			_ = r.Status().Update(ctx, cc.claim)
 			return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 		}
-		logger.Info("Pod is ready")
+		cc.logger.Info("Pod is ready")
+		if cc.claim.Status.Status != backupsv1beta1.StatusReady {
+			for _, p := range existingPods {
+				r.recordRepeatedEvent(cc.claim, EventReasonPodReady, "Destination pod %s/%s is running", p.Namespace, p.Name)
+			}
+		}
+	}
+
+	// Pods replaced since the delivery do not hold the backup
+	destinationPods := existingPods
+	if childPod != nil {
+		destinationPods = []corev1.Pod{*childPod}
+	}
+	if reason := lostPods(cc.claim, destinationPods); reason != "" {
+		if err := r.recoverBackup(cc, reason); err != nil {
+			r.degraded(ctx, cc, err)
+			return ctrl.Result{}, nil
+		}
 	}
 
 	// Handle Source
-	if backupClaim.Spec.Source.S3.BucketName != "" {
-		s3file, wait, err = r.HandleSourceS3(ctx, req)
+	if cc.claim.Spec.Source.S3.BucketName != "" {
+		s3file, wait, err = r.HandleSourceS3(ctx, cc)
 		if err != nil {
-			backupClaim.Status.Status = backupsv1beta1.StatusFailedToResolveSource
-			backupClaim.Status.Error = err.Error()
-			_ = r.Status().Update(ctx, &backupClaim)
+			r.recordFailure(cc.claim, EventReasonFailedToResolveSource, "Could not resolve source: %s", err.Error())
+			cc.claim.Status.Status = backupsv1beta1.StatusFailedToResolveSource
+			cc.claim.Status.Error = err.Error()
+			_ = r.Status().Update(ctx, cc.claim)
 			return ctrl.Result{}, err
 			// If we have to wait, return
 		} else if wait {
-			return ctrl.Result{}, nil
+			// Glacier restores take minutes to hours
+			cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+			cc.claim.Status.Error = ""
+			_ = r.Status().Update(ctx, cc.claim)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
 		}
 	}
 
-	if backupClaim.Spec.Source.S3.BucketName != "" && backupClaim.Spec.Destination.Pod.NamePrefix != "" {
-		err = r.HandleSourceS3ToDestinationPod(ctx, req, childPod, s3file)
+	// Handle PVC Destination, sized from the source
+	if cc.claim.Spec.Destination.PVC.Name != "" && s3file != nil {
+		childPod, wait, err = r.HandleDestinationPVC(ctx, cc, s3file)
+		if err != nil {
+			cc.logger.Error(err, "Could not check volume status")
+			r.recordFailure(cc.claim, EventReasonFailedToResolveDestination, "Could not resolve destination volume: %s", err.Error())
+			cc.claim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
+			cc.claim.Status.Error = err.Error()
+			_ = r.Status().Update(ctx, cc.claim)
+			return ctrl.Result{}, err
+			// If we have to wait, return
+		} else if wait {
+			remaining, err := podReadyTimeout(cc.claim, time.Now())
+			if err != nil {
+				r.timedOut(ctx, cc, err)
+				return ctrl.Result{}, nil
+			}
+			cc.logger.Info("Loader pod is not ready")
+			r.recordRepeatedEvent(cc.claim, EventReasonWaitingForPod, "Waiting for the loader pod to be running")
+			cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+			cc.claim.Status.Error = ""
+			_ = r.Status().Update(ctx, cc.claim)
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
 	}
-	if backupClaim.Spec.Source.S3.BucketName != "" && backupClaim.Spec.Destination.ExistingPod.Name != "" {
-		err = r.HandleSourceS3ToDestinationExistingPod(ctx, req, childPod, s3file)
+
+	if cc.claim.Spec.Source.S3.BucketName != "" && cc.claim.Spec.Destination.Pod.NamePrefix != "" {
+		err = r.HandleSourceS3ToDestinationPod(ctx, cc, childPod, s3file)
+	}
+	if cc.claim.Spec.Source.S3.BucketName != "" && cc.claim.Spec.Destination.ExistingPod.Namespace != "" {
+		for i := range existingPods {
+			if err = r.HandleSourceS3ToDestinationExistingPod(ctx, cc, &existingPods[i], s3file); err != nil {
+				break
+			}
+		}
+	}
+	wait = false
+	if cc.claim.Spec.Source.S3.BucketName != "" && cc.claim.Spec.Destination.PVC.Name != "" {
+		wait, err = r.HandleSourceS3ToDestinationPVC(ctx, cc, childPod, s3file)
+	}
+	var timeout *timeoutError
+	if errors.As(err, &timeout) {
+		r.timedOut(ctx, cc, err)
+		return ctrl.Result{}, nil
 	}
 	if err != nil {
-		logger.Error(err, "fail to send backup to destination")
-		backupClaim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
-		backupClaim.Status.Error = fmt.Sprintf("fail to send backup to destination: %s", err.Error())
-		_ = r.Status().Update(ctx, &backupClaim)
+		cc.logger.Error(err, "fail to send backup to destination")
+		r.recordFailure(cc.claim, EventReasonTransferFailed, "Failed to send backup to destination: %s", err.Error())
+		cc.claim.Status.Status = backupsv1beta1.StatusFailedToResolveDestination
+		cc.claim.Status.Error = fmt.Sprintf("fail to send backup to destination: %s", err.Error())
+		_ = r.Status().Update(ctx, cc.claim)
 		return ctrl.Result{}, err
+	} else if wait {
+		// The volume is snapshotted once the loader pod released it
+		cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+		cc.claim.Status.Error = ""
+		_ = r.Status().Update(ctx, cc.claim)
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 	}
 
-	backupClaim.Status.Status = backupsv1beta1.StatusReady
-	_ = r.Status().Update(ctx, &backupClaim)
+	// Loader pods of PVC destinations are deleted, the volume holds the backup
+	if cc.claim.Spec.Destination.PVC.Name == "" {
+		cc.claim.Status.DeliveredTo = deliveredPods(destinationPods)
+	}
+	cc.claim.Status.Status = backupsv1beta1.StatusReady
+	_ = r.Status().Update(ctx, cc.claim)
+	r.eventThrottle().Forget(req.NamespacedName)
 	return ctrl.Result{}, nil
 }
 
-func (r *BackupClaimReconciler) HandleSourceS3ToDestinationExistingPod(ctx context.Context, req ctrl.Request, childPod *corev1.Pod, s3file *source.S3File) error {
-	var path = fmt.Sprintf("/tmp/%s/%s", backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key)
-	podExec := pod.NewPodExec(
-		*r.RestConfig,
-		r.ClientSet,
-		childPod.Namespace,
-		childPod.Name,
-		childPod.Spec.Containers[0].Name,
-	)
+func (r *BackupClaimReconciler) HandleSourceS3ToDestinationExistingPod(ctx context.Context, cc *claimContext, childPod *corev1.Pod, s3file *source.S3File) error {
+	container, err := existingPodContainer(childPod, cc.claim.Spec.Destination.ExistingPod.Container)
+	if err != nil {
+		return err
+	}
+	path, err := existingPodPath(cc.claim, container)
+	if err != nil {
+		return err
+	}
+	podExec := r.NewExecutor(childPod, container.Name)
 	// Check if we _really_ need to upload everything again
-	if backupClaim.Status.Status == backupsv1beta1.StatusReady {
-		// Check if file exists
-		_, out, _, _ := podExec.ExecCmd([]string{"bash", "-c", fmt.Sprintf("(test -f %s && echo '0') || echo '1';", path)})
-		if out.String() != "0" {
-			logger.Info("Backup claim is already ready")
-			return nil
-		}
+	if delivered, err := isDelivered(ctx, cc, podExec, path, s3file); err != nil || delivered {
+		return err
+	}
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"mkdir", "-p", filepath.Dir(path)})); err != nil {
+		return fmt.Errorf("Could not create folder '%s': %v", filepath.Dir(path), err)
+	}
+	// Writes are appended, start from an empty file. The manifest would
+	// vouch for a partial backup if the transfer is interrupted.
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"rm", "-f", path, manifestPath(path)})); err != nil {
+		return fmt.Errorf("Could not remove previous backup '%s': %v", path, err)
 	}
-	podFile := pod.NewPodFile(
-		path,
-		podExec,
-	)
-	_, _, _, _ = podExec.ExecCmd([]string{"mkdir", "-p", filepath.Dir(path)})
 
-	logger.Info("Uploading file to pod", "namespace", childPod.Namespace, "podname", childPod.Name, "containerName", childPod.Spec.Containers[0].Name)
-	if _, err := io.Copy(podFile, s3file); err != nil {
-		return fmt.Errorf("Unable to copy file in pod: %s", err.Error())
+	cc.logger.Info("Uploading file to pod", "namespace", childPod.Namespace, "podname", childPod.Name, "containerName", container.Name)
+	checksum, err := r.transfer(ctx, cc, s3file, podExec, childPod, path)
+	if err != nil {
+		return err
 	}
-	return nil
+	return writeManifest(ctx, podExec, path, newManifest(cc.claim, s3file, checksum))
 }
 
-func (r *BackupClaimReconciler) HandleSourceS3ToDestinationPod(ctx context.Context, req ctrl.Request, childPod *corev1.Pod, s3file *source.S3File) error {
-	logger.WithValues("namespace", childPod.Namespace, "podname", childPod.Name, "containerName", childPod.Spec.Containers[0].Name)
-	// Generate backupname
-	var backupname = backupClaim.Spec.Source.S3.Key
-	// Remove all extension and replace all / by underscore
-	backupname = strings.TrimSuffix(backupname, filepath.Ext(backupname))
-	backupname = strings.TrimSuffix(backupname, filepath.Ext(backupname))
-	backupname = strings.ReplaceAll(backupname, "/", "")
-	backupname = strings.ReplaceAll(backupname, "-", "")
-	backupname = strings.ReplaceAll(backupname, ".", "")
-
-	var path = fmt.Sprintf("/tmp/%s/%s", backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key)
-
-	podExec := pod.NewPodExec(
-		*r.RestConfig,
-		r.ClientSet,
-		childPod.Namespace,
-		childPod.Name,
-		childPod.Spec.Containers[0].Name,
-	)
+func (r *BackupClaimReconciler) HandleSourceS3ToDestinationPod(ctx context.Context, cc *claimContext, childPod *corev1.Pod, s3file *source.S3File) error {
+	cc.logger.WithValues("namespace", childPod.Namespace, "podname", childPod.Name, "containerName", childPod.Spec.Containers[0].Name)
+	var backupname = databaseName(cc.claim)
 
-	// Check if we _really_ need to upload everything again
-	if backupClaim.Status.Status == backupsv1beta1.StatusReady {
-		// Check if database exists
-		_, out, _, _ := podExec.ExecCmd([]string{"bash", "-c", fmt.Sprintf("(test -d /var/lib/mysql/%s && echo '0') || echo '1';", backupname)})
-		if out.String() != "0" {
-			logger.Info("Backup claim is already ready")
-			return nil
-		}
+	var path = fmt.Sprintf("/tmp/%s/%s", cc.claim.Spec.Source.S3.BucketName, deliveredKey(cc.claim))
+
+	podExec := r.NewExecutor(childPod, childPod.Spec.Containers[0].Name)
+
+	// Check if we _really_ need to upload everything again. The manifest is
+	// written once the backup is imported.
+	manifest, err := readManifest(ctx, podExec, path)
+	if err != nil {
+		return err
+	}
+	if manifest != nil && manifest.matches(cc.claim, s3file) {
+		cc.logger.Info("Backup claim is already ready")
+		return nil
+	}
+	cc.logger.Info(fmt.Sprintf("Creating folder '%s'", filepath.Dir(path)))
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"mkdir", "-p", filepath.Dir(path)})); err != nil {
+		return fmt.Errorf("Could not create folder '%s': %v", filepath.Dir(path), err)
+	}
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"rm", "-f", path})); err != nil {
+		return fmt.Errorf("Could not remove previous backup '%s': %v", path, err)
+	}
+
+	cc.logger.Info("Uploading file to pod")
+	checksum, err := r.transfer(ctx, cc, s3file, podExec, childPod, path)
+	if err != nil {
+		return err
 	}
-	podFile := pod.NewPodFile(
-		path,
-		podExec,
-	)
 
-	logger.Info(fmt.Sprintf("Creating folder '%s'", filepath.Dir(path)), )
-	_, _, _, _ = podExec.ExecCmd([]string{"mkdir", "-p", filepath.Dir(path)})
-	_, _, _, _ = podExec.ExecCmd([]string{"rm", "-f", path})
+	if err := r.importMySQL(ctx, cc, childPod, path, backupname); err != nil {
+		return err
+	}
+	if err := r.grantDatabase(ctx, cc, podExec, backupname); err != nil {
+		return err
+	}
+	dump := strings.TrimSuffix(path, filepath.Ext(path))
 
-	logger.Info("Uploading file to pod")
-	if _, err := io.Copy(podFile, s3file); err != nil {
-		return fmt.Errorf("Unable to copy file in pod: %s", err.Error())
+	// The dump is in the database now, free the disk of the pod
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"rm", "-f", dump})); err != nil {
+		return fmt.Errorf("Could not remove '%s': %v", dump, err)
 	}
-	_, _, _, _ = podExec.ExecCmd([]string{"mysql", "-e", fmt.Sprintf("CREATE DATABASE %s", backupname)})
-	_, _, _, _ = podExec.ExecCmd([]string{"xz", "-d", path})
-	_, _, _, _ = podExec.ExecCmd([]string{"bash", "-c", fmt.Sprintf("mysql %s < %s", backupname, strings.TrimSuffix(path, filepath.Ext(path)))})
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonCleanedUp, "Removed %s from the pod", dump)
+
+	return writeManifest(ctx, podExec, path, newManifest(cc.claim, s3file, checksum))
+}
 
+// importMySQL decompresses the backup at path and imports it in database
+func (r *BackupClaimReconciler) importMySQL(ctx context.Context, cc *claimContext, childPod *corev1.Pod, path, database string) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Import", trace.WithAttributes(
+		attribute.String("import.engine", engineMySQL),
+		attribute.String("import.database", database),
+	))
+	defer func() { tracing.End(span, err) }()
+	ctx, cancel := withTimeout(ctx, cc.claim.Spec.Timeouts.Import)
+	defer cancel()
+	podExec := r.NewExecutor(childPod, childPod.Spec.Containers[0].Name)
+
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonImportStarted, "Importing backup in database %s", database)
+	quoted, err := engine.QuoteIdentifier(engine.MySQL, database)
+	if err != nil {
+		return fmt.Errorf("Unable to import backup in database '%s': %s", database, err.Error())
+	}
+	start := time.Now()
+	// Retries run on what the previous attempt left. The dump is streamed to
+	// mysql, its path never reaches the SQL parser.
+	for _, command := range []*pod.Command{
+		pod.NewCommand("mysql", "-e", "CREATE DATABASE IF NOT EXISTS "+quoted),
+		pod.NewCommand("xz", "-d", "-f", path),
+		pod.NewCommand("mysql", "--database="+database).StdinFile(strings.TrimSuffix(path, filepath.Ext(path))),
+	} {
+		if _, err := pod.MustSucceed(command.Run(ctx, podExec)); err != nil {
+			if err := stepTimedOut(ctx, stepImport, cc.claim.Spec.Timeouts.Import); err != nil {
+				return err
+			}
+			r.recordFailure(cc.claim, EventReasonImportFailed, "Failed to import backup in database %s: %s", database, err.Error())
+			return fmt.Errorf("Unable to import backup in database '%s': %s", database, err.Error())
+		}
+	}
+	importDuration.WithLabelValues(cc.claim.Namespace, engineMySQL).Observe(time.Since(start).Seconds())
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonImportFinished, "Imported backup in database %s", database)
 	return nil
 }
 
-func (r *BackupClaimReconciler) HandleSourceS3(ctx context.Context, req ctrl.Request) (*source.S3File, bool, error) {
+// transfer copies the backup to a file of the destination pod and returns the
+// sha256 of the bytes written
+func (r *BackupClaimReconciler) transfer(ctx context.Context, cc *claimContext, s3file *source.S3File, podExec pod.Executor, childPod *corev1.Pod, path string) (checksum string, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Transfer", trace.WithAttributes(
+		attribute.String("transfer.source", s3file.URL()),
+		attribute.String("transfer.destination", fmt.Sprintf("%s/%s:%s", childPod.Namespace, childPod.Name, path)),
+	))
+	defer func() { tracing.End(span, err) }()
+	ctx, cancel := withTimeout(ctx, cc.claim.Spec.Timeouts.Transfer)
+	defer cancel()
+	s3file.SetContext(ctx)
+	podFile := podExec.File(ctx, path)
+	defer podFile.Close()
+
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonTransferStarted, "Sending %s to %s/%s:%s", s3file.URL(), childPod.Namespace, childPod.Name, path)
+	backup, err := r.OpenSource(ctx, cc, s3file)
+	if err != nil {
+		return "", err
+	}
+	transfersInFlight.WithLabelValues(cc.claim.Namespace).Inc()
+	defer transfersInFlight.WithLabelValues(cc.claim.Namespace).Dec()
+	start := time.Now()
+	hash := sha256.New()
+	written, err := io.Copy(podFile, io.TeeReader(backup, hash))
+	if err != nil {
+		if err := stepTimedOut(ctx, stepTransfer, cc.claim.Spec.Timeouts.Transfer); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("Unable to copy file in pod: %s", err.Error())
+	}
+	transferDuration.WithLabelValues(cc.claim.Namespace, sourceTypeS3).Observe(time.Since(start).Seconds())
+	transferBytes.WithLabelValues(cc.claim.Namespace, sourceTypeS3).Observe(float64(written))
+	span.SetAttributes(attribute.Int64("transfer.bytes", written))
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonTransferFinished, "Sent %d bytes to %s/%s:%s", written, childPod.Namespace, childPod.Name, path)
+	return fmt.Sprintf("sha256:%x", hash.Sum(nil)), nil
+}
 
-	s3file, err := source.NewS3File(ctx, backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key, s3.New(r.AwsSession))
+func (r *BackupClaimReconciler) HandleSourceS3(ctx context.Context, cc *claimContext) (s3file *source.S3File, wait bool, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "HandleSourceS3", trace.WithAttributes(
+		attribute.String("s3.bucket", cc.claim.Spec.Source.S3.BucketName),
+		attribute.String("s3.key", cc.claim.Spec.Source.S3.Key),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Bool("source.wait", wait))
+		tracing.End(span, err)
+	}()
+
+	var roleARN, externalID string
+	if assumeRole := cc.claim.Spec.Source.S3.AssumeRole; assumeRole != nil {
+		roleARN, externalID = assumeRole.RoleARN, assumeRole.ExternalID
+	}
+	s3client := r.NewS3Client(roleARN, externalID, source.RoleSessionName(cc.claim.Namespace, cc.claim.Name))
+
+	s3file, err = source.NewS3File(ctx, cc.claim.Spec.Source.S3.BucketName, cc.claim.Spec.Source.S3.Key, s3client)
+	if err != nil {
+		return nil, true, fmt.Errorf("Could not initialize s3file '%s'.'%s': %s", cc.claim.Spec.Source.S3.BucketName, cc.claim.Spec.Source.S3.Key, err.Error())
+	}
+	if ref := cc.claim.Spec.Source.S3.SSECustomerKeySecretRef; ref != nil {
+		key, err := r.GetSecretKey(ctx, cc.claim.Namespace, ref)
+		if err != nil {
+			return nil, true, err
+		}
+		if s3file.SSECustomerKey, err = source.ParseSSECustomerKey(key); err != nil {
+			return nil, true, err
+		}
+	}
+	// File is in Glacier, request a restore once and wait until it's done
+	state, err := s3file.GetGlacierState()
 	if err != nil {
-		return nil, true, fmt.Errorf("Could not initialize s3file '%s'.'%s': %s", backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key, err.Error())
+		return nil, true, err
 	}
-	// File is in Glacier, we have to wait
-	if s3file.IsGlacier() {
+	switch state {
+	case source.GlacierStateArchived:
+		cc.logger.Info("Restoring s3file from glacier", "s3file", s3file.URL())
 		err := s3file.RestoreFromGlacier()
 		if err != nil {
-			return nil, true, fmt.Errorf("Could not restore s3file from glacier '%s'.'%s': %s", backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key, err.Error())
+			return nil, true, fmt.Errorf("Could not restore s3file from glacier '%s'.'%s': %s", cc.claim.Spec.Source.S3.BucketName, cc.claim.Spec.Source.S3.Key, err.Error())
 		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonGlacierRestoreRequested, "Requested the restore of %s from glacier", s3file.URL())
+		r.glacierRequests.Store(cc.claim.UID, time.Now())
+		return nil, true, nil
+	case source.GlacierStateRestoring:
+		cc.logger.Info("Waiting for s3file to be restored from glacier", "s3file", s3file.URL())
+		r.recordRepeatedEvent(cc.claim, EventReasonWaitingForGlacier, "Waiting for %s to be restored from glacier", s3file.URL())
 		return nil, true, nil
+	case source.GlacierStateRestored:
+		if cc.claim.Status.Status != backupsv1beta1.StatusReady {
+			r.recordRepeatedEvent(cc.claim, EventReasonGlacierRestoreCompleted, "%s was restored from glacier", s3file.URL())
+			// Only known if the restore was requested since the operator started
+			if requestedAt, ok := r.glacierRequests.LoadAndDelete(cc.claim.UID); ok {
+				glacierWaitDuration.WithLabelValues(cc.claim.Namespace).Observe(time.Since(requestedAt.(time.Time)).Seconds())
+			}
+		}
 	}
 
 	// Everything is ready to go
 	return s3file, false, nil
 }
 
-func (r *BackupClaimReconciler) HandleDestinationPod(ctx context.Context, req ctrl.Request) (*corev1.Pod, bool, error) {
-	logger.Info("Checking if pod exists")
+// OpenSource returns the reader of the backup, decrypting it on the fly if
+// needed
+func (r *BackupClaimReconciler) OpenSource(ctx context.Context, cc *claimContext, backup io.Reader) (io.Reader, error) {
+	spec := cc.claim.Spec.Source.Decryption
+	if spec == nil {
+		return backup, nil
+	}
+	decryption := source.Decryption{Type: spec.Type}
+	var err error
+	if spec.KeySecretRef != nil {
+		if decryption.Key, err = r.GetSecretKey(ctx, cc.claim.Namespace, spec.KeySecretRef); err != nil {
+			return nil, err
+		}
+	}
+	if spec.PassphraseSecretRef != nil {
+		if decryption.Passphrase, err = r.GetSecretKey(ctx, cc.claim.Namespace, spec.PassphraseSecretRef); err != nil {
+			return nil, err
+		}
+	}
+	reader, err := source.NewDecryptReader(backup, decryption)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decrypt backup: %s", err.Error())
+	}
+	return reader, nil
+}
+
+// GetSecretKey returns the value of a key of a secret
+func (r *BackupClaimReconciler) GetSecretKey(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: selector.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("Could not get secret '%s': %s", selector.Name, err.Error())
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("Key '%s' not found in secret '%s'", selector.Key, selector.Name)
+	}
+	return value, nil
+}
+
+func (r *BackupClaimReconciler) HandleDestinationPod(ctx context.Context, cc *claimContext) (*corev1.Pod, bool, error) {
+	if err := r.ensureConnection(ctx, cc); err != nil {
+		return nil, true, err
+	}
+	cc.logger.Info("Checking if pod exists")
 	var childPods corev1.PodList
-	err := r.List(ctx, &childPods, client.InNamespace(req.Namespace), client.MatchingFields{jobOwnerKey: req.Name})
+	err := r.List(ctx, &childPods, client.InNamespace(cc.claim.Namespace), client.MatchingFields{jobOwnerKey: cc.claim.Name})
 	if err != nil {
 		return nil, true, err
 	}
+	childPod := ownedPod(cc.claim, childPods.Items)
+	// Failed pods never run again, replace them
+	if childPod != nil && (childPod.Status.Phase == corev1.PodFailed || childPod.Status.Phase == corev1.PodSucceeded) {
+		if len(cc.claim.Status.DeliveredTo) != 0 {
+			reason := fmt.Sprintf("pod %s/%s %s", childPod.Namespace, childPod.Name, strings.ToLower(string(childPod.Status.Phase)))
+			if err := r.recoverBackup(cc, reason); err != nil {
+				return nil, true, err
+			}
+		}
+		if err := r.Delete(ctx, childPod); client.IgnoreNotFound(err) != nil {
+			return nil, true, fmt.Errorf("Could not delete pod: %s", err.Error())
+		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonPodReplaced, "Deleted %s destination pod %s/%s", strings.ToLower(string(childPod.Status.Phase)), childPod.Namespace, childPod.Name)
+		childPod = nil
+	}
+	// The cache may not have seen the pod created by the previous reconcile
+	// yet, ask the API server before creating another one
+	if childPod == nil && cc.claim.Status.PodName != "" {
+		exists, err := r.recordedPodExists(ctx, cc.claim)
+		if err != nil || exists {
+			return nil, true, err
+		}
+	}
 	// If the pod does not exists, recreate it.
-	if len(childPods.Items) == 0 {
+	if childPod == nil {
+		if delivered := cc.claim.Status.DeliveredTo; len(delivered) != 0 {
+			reason := fmt.Sprintf("pod %s/%s was deleted", delivered[0].Namespace, delivered[0].Name)
+			if err := r.recoverBackup(cc, reason); err != nil {
+				return nil, true, err
+			}
+		}
 		// RECREATE IT YOU CRAZY BASTERD
-		logger.Info("Pod does not exist, creating it.")
-		newPod := pod.CreatePodSpec(backupClaim.Spec.Destination.Pod, req.Namespace)
-		if err := ctrl.SetControllerReference(&backupClaim, &newPod, r.Scheme); err != nil {
+		cc.logger.Info("Pod does not exist, creating it.")
+		newPod, err := pod.CreatePodSpec(cc.claim, pod.CredentialsSecretName(cc.claim.Name))
+		if err != nil {
+			return nil, true, fmt.Errorf("Could not create a new pod: %s", err.Error())
+		}
+		if newPod.Labels == nil {
+			newPod.Labels = make(map[string]string)
+		}
+		newPod.Labels[claimLabel] = string(cc.claim.UID)
+		if err := ctrl.SetControllerReference(cc.claim, &newPod, r.Scheme); err != nil {
 			return nil, true, fmt.Errorf("Could not create a new pod: %s", err.Error())
 		}
 
 		if err := r.Create(ctx, &newPod); err != nil {
 			return nil, true, fmt.Errorf("Could not create a new pod: %s", err.Error())
 		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonPodCreated, "Created destination pod %s/%s", newPod.Namespace, newPod.Name)
+		cc.claim.Status.PodName = newPod.Name
 		return nil, true, err
 	}
+	cc.claim.Status.PodName = childPod.Name
 
-	// If the pod is ready, return pod and keep going
-	if childPods.Items[0].Status.Phase == corev1.PodRunning {
-		return &childPods.Items[0], false, nil
-	} else {
-		// If the pod is not ready, we have to wait
+	// If the pod is not ready, we have to wait
+	if childPod.Status.Phase != corev1.PodRunning || !containerReady(childPod, childPod.Spec.Containers[0].Name) {
 		return nil, true, nil
 	}
+	// If the database accepts connections, return pod and keep going
+	ready, err := r.databaseReady(ctx, cc, childPod, childPod.Spec.Containers[0].Name, engine.MySQL)
+	if err != nil || !ready {
+		return nil, true, err
+	}
+	return childPod, false, nil
+}
+
+// recordedPodExists tells if the API server still has the pod recorded in the
+// status of the claim
+func (r *BackupClaimReconciler) recordedPodExists(ctx context.Context, backupClaim *backupsv1beta1.BackupClaim) (bool, error) {
+	var recorded corev1.Pod
+	key := client.ObjectKey{Namespace: backupClaim.Namespace, Name: backupClaim.Status.PodName}
+	if err := r.APIReader.Get(ctx, key, &recorded); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Could not get pod '%s': %s", key.Name, err.Error())
+	}
+	return metav1.IsControlledBy(&recorded, backupClaim), nil
 }
 
-func (r *BackupClaimReconciler) HandleDestinationExistingPod(ctx context.Context, req ctrl.Request) (*corev1.Pod, bool, error) {
-	logger.Info("Checking if existing pod exists")
+// ownedPod returns the pod of the claim among pods, listed by the name of
+// their owner, or nil if it has none
+func ownedPod(backupClaim *backupsv1beta1.BackupClaim, pods []corev1.Pod) *corev1.Pod {
+	for i := range pods {
+		// Skip the pods of a deleted claim of the same name
+		if metav1.IsControlledBy(&pods[i], backupClaim) {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// HandleDestinationExistingPod returns the pods receiving the backup: the pod
+// named in the destination, or the pods of its workload or selector picked by
+// its policy
+func (r *BackupClaimReconciler) HandleDestinationExistingPod(ctx context.Context, cc *claimContext) ([]corev1.Pod, bool, error) {
+	spec := cc.claim.Spec.Destination.ExistingPod
+	cc.logger.Info("Checking if existing pod exists")
 	var childPods corev1.PodList
-	err := r.List(ctx, &childPods, client.InNamespace(backupClaim.Spec.Destination.ExistingPod.Namespace), client.MatchingFields{".metadata.name": backupClaim.Spec.Destination.ExistingPod.Name})
+	if spec.Name != "" {
+		err := r.List(ctx, &childPods, client.InNamespace(spec.Namespace), client.MatchingFields{".metadata.name": spec.Name})
+		if err != nil {
+			return nil, true, err
+		}
+		// If the pod does not exists, just fail
+		if len(childPods.Items) == 0 {
+			cc.logger.Info("Pod does not exist")
+			if len(cc.claim.Status.DeliveredTo) != 0 {
+				if err := r.recoverBackup(cc, fmt.Sprintf("pod %s/%s was deleted", spec.Namespace, spec.Name)); err != nil {
+					return nil, true, err
+				}
+			}
+			return nil, true, fmt.Errorf("Could not find pod in namesapce '%s' with name '%s'", spec.Namespace, spec.Name)
+		}
+
+		// Failed pods are never ready again, do not wait for them to tell
+		if reason := lostPods(cc.claim, childPods.Items[:1]); reason != "" {
+			if err := r.recoverBackup(cc, reason); err != nil {
+				return nil, true, err
+			}
+		}
+		// If the container is ready, return pod and keep going
+		if wait, err := checkExistingPod(cc.claim, &childPods.Items[0]); err != nil || wait {
+			return nil, true, err
+		}
+		return r.existingPodsReady(ctx, cc, childPods.Items[:1])
+	}
+
+	selector, err := r.existingPodsSelector(ctx, spec)
 	if err != nil {
 		return nil, true, err
 	}
-	// If the pod does not exists, just fail
-	if len(childPods.Items) == 0 {
-		logger.Info("Pod does not exist")
-		return nil, true, fmt.Errorf("Could not find pod in namesapce '%s' with name '%s'", backupClaim.Spec.Destination.ExistingPod.Namespace, backupClaim.Spec.Destination.ExistingPod.Name)
+	if err := r.List(ctx, &childPods, client.InNamespace(spec.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, true, err
+	}
+	pods, wait, err := selectExistingPods(spec, childPods.Items)
+	if err != nil || wait {
+		return nil, true, err
 	}
+	for i := range pods {
+		if wait, err := checkExistingPod(cc.claim, &pods[i]); err != nil || wait {
+			return nil, true, err
+		}
+	}
+	return r.existingPodsReady(ctx, cc, pods)
+}
 
-	// If pod is ready, return pod and keep going
-	if childPods.Items[0].Status.Phase == corev1.PodRunning {
-		return &childPods.Items[0], false, nil
-	} else {
-		// If the pod is not ready, we have to wait
-		return nil, true, nil
+// existingPodsReady returns pods once the database of the destination, if
+// any, accepts connections in all of them
+func (r *BackupClaimReconciler) existingPodsReady(ctx context.Context, cc *claimContext, pods []corev1.Pod) ([]corev1.Pod, bool, error) {
+	spec := cc.claim.Spec.Destination.ExistingPod
+	if spec.Engine == "" {
+		return pods, false, nil
 	}
+	for i := range pods {
+		container, err := existingPodContainer(&pods[i], spec.Container)
+		if err != nil {
+			return nil, true, err
+		}
+		ready, err := r.databaseReady(ctx, cc, &pods[i], container.Name, spec.Engine)
+		if err != nil || !ready {
+			return nil, true, err
+		}
+	}
+	return pods, false, nil
+}
+
+// deliveredKey is the key of the backup once delivered, without the
+// extension of the encryption tool when it is decrypted
+func deliveredKey(backupClaim *backupsv1beta1.BackupClaim) string {
+	if backupClaim.Spec.Source.Decryption != nil {
+		return source.TrimEncryptedExtension(backupClaim.Spec.Source.S3.Key)
+	}
+	return backupClaim.Spec.Source.S3.Key
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BackupClaimReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
 	// Configure AWS session
-	sess, err := session.NewSession()
+	sess, err := source.NewSession(r.AwsWebIdentityRoleARN, r.AwsWebIdentityTokenFile)
 	if err != nil {
 		return fmt.Errorf("could not initialize aws session: %v", err)
 	}
 	r.AwsSession = sess
+	r.AwsRoles = source.NewAssumeRoleCache(sess)
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	if r.NewS3Client == nil {
+		r.NewS3Client = func(roleARN, externalID, sessionName string) s3iface.S3API {
+			return r.AwsRoles.S3Client(roleARN, externalID, sessionName)
+		}
+	}
 
 	// Make sure RestConfig has sane defaults
 	r.RestConfig = mgr.GetConfig()
@@ -340,32 +826,80 @@ func (r *BackupClaimReconciler) SetupWithManager(mgr ctrl.Manager) (err error) {
 	if err != nil {
 		return fmt.Errorf("could not initializing kubernetes client: %v", err)
 	}
+	if r.NewExecutor == nil {
+		r.NewExecutor = pod.NewExecutorFactory(r.RestConfig, r.ClientSet)
+	}
 
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, jobOwnerKey, func(rawObj client.Object) []string {
-		// grab the pod object, extract the owner...
-		podObj := rawObj.(*corev1.Pod)
-		owner := metav1.GetControllerOf(podObj)
-		if owner == nil {
-			return nil
-		}
-		if owner.APIVersion != apiGVStr || owner.Kind != "BackupClaim" {
-			return nil
-		}
-		return []string{owner.Name}
-	}); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, jobOwnerKey, podOwnerIndex); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, ".metadata.name", podNameIndex); err != nil {
 		return err
 	}
 
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, ".metadata.name", func(rawObj client.Object) []string {
-		// grab the pod object, extract the owner...
-		podObj := rawObj.(*corev1.Pod)
-		return []string{podObj.Name}
-	}); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &backupsv1beta1.BackupClaim{}, deliveredPodKey, deliveredPodsIndex); err != nil {
 		return err
 	}
 
+	if err := registerClaimsCollector(mgr.GetClient()); err != nil {
+		return fmt.Errorf("could not register metrics: %v", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&backupsv1beta1.BackupClaim{}).
 		Owns(&corev1.Pod{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.Service{}).
+		// Existing pods are not owned by the claims delivering to them
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.claimsDeliveredTo)).
+		Watches(&backupsv1beta1.BackupSourcePolicy{}, handler.EnqueueRequestsFromMapFunc(r.claimsDeniedByPolicy)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
+
+// claimsDeniedByPolicy returns the claims denied by the policies, to check
+// them again when a policy changes
+func (r *BackupClaimReconciler) claimsDeniedByPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	var claims backupsv1beta1.BackupClaimList
+	if err := r.List(ctx, &claims); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, claim := range claims.Items {
+		if claim.Status.Status == backupsv1beta1.StatusDeniedByPolicy {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}})
+		}
+	}
+	return requests
+}
+
+// podOwnerIndex indexes pods by the name of the claim controlling them
+func podOwnerIndex(rawObj client.Object) []string {
+	// grab the pod object, extract the owner...
+	podObj := rawObj.(*corev1.Pod)
+	owner := metav1.GetControllerOf(podObj)
+	if owner == nil {
+		return nil
+	}
+	if owner.APIVersion != apiGVStr || owner.Kind != "BackupClaim" {
+		return nil
+	}
+	return []string{owner.Name}
+}
+
+// podNameIndex indexes pods by name
+func podNameIndex(rawObj client.Object) []string {
+	podObj := rawObj.(*corev1.Pod)
+	return []string{podObj.Name}
+}
+
+// deliveredPodsIndex indexes claims by the pods they delivered to
+func deliveredPodsIndex(rawObj client.Object) []string {
+	claim := rawObj.(*backupsv1beta1.BackupClaim)
+	var keys []string
+	for _, d := range claim.Status.DeliveredTo {
+		keys = append(keys, fmt.Sprintf("%s/%s", d.Namespace, d.Name))
+	}
+	return keys
+}
@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	podfake "github.com/nvanheuverzwijn/backup-operator/pkg/pod/fake"
+	s3fake "github.com/nvanheuverzwijn/backup-operator/pkg/source/fake"
+)
+
+const (
+	timeout  = time.Second * 10
+	interval = time.Millisecond * 250
+
+	bucket = "db-backup.example.com"
+)
+
+var _ = Describe("BackupClaim controller", func() {
+	var (
+		ctx       context.Context
+		namespace string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakePods.Reset()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "backupclaim-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		namespace = ns.Name
+	})
+
+	newClaim := func(name, key string, destination backupsv1beta1.BackupClaimDestinationSpec) *backupsv1beta1.BackupClaim {
+		return &backupsv1beta1.BackupClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: backupsv1beta1.BackupClaimSpec{
+				Source: backupsv1beta1.BackupClaimSourceSpec{
+					S3: backupsv1beta1.BackupClaimS3SourceSpec{BucketName: bucket, Key: key},
+				},
+				Destination: destination,
+			},
+		}
+	}
+
+	existingPod := func(name string) backupsv1beta1.BackupClaimDestinationSpec {
+		return backupsv1beta1.BackupClaimDestinationSpec{
+			ExistingPod: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Namespace: namespace, Name: name},
+		}
+	}
+
+	createRunningPod := func(name string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "mysql", Image: "mysql:latest"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		setPodRunning(ctx, pod)
+		return pod
+	}
+
+	claimStatus := func(claim *backupsv1beta1.BackupClaim) func() string {
+		return func() string {
+			var current backupsv1beta1.BackupClaim
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &current); err != nil {
+				return ""
+			}
+			return current.Status.Status
+		}
+	}
+
+	// Touching the claim triggers a reconcile without waiting for the requeue
+	touch := func(claim *backupsv1beta1.BackupClaim) {
+		Eventually(func() error {
+			var current backupsv1beta1.BackupClaim
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &current); err != nil {
+				return err
+			}
+			if current.Annotations == nil {
+				current.Annotations = map[string]string{}
+			}
+			current.Annotations["test/touched"] = time.Now().String()
+			return k8sClient.Update(ctx, &current)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	Context("with a pod destination", func() {
+		It("creates the pod and delivers the backup once it runs", func() {
+			key := "2021/12/01/pod__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			claim := newClaim("restore", key, backupsv1beta1.BackupClaimDestinationSpec{
+				Pod: backupsv1beta1.BackupClaimNewPodDestinationSpec{NamePrefix: "restore"},
+			})
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			By("creating the pod owned by the claim")
+			var pod corev1.Pod
+			Eventually(claimPod(ctx, claim, &pod), timeout, interval).Should(Succeed())
+			Expect(pod.Name).To(HavePrefix("restore-restore-"))
+			owner := metav1.GetControllerOf(&pod)
+			Expect(owner).NotTo(BeNil())
+			Expect(owner.Name).To(Equal(claim.Name))
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReconciling))
+
+			By("delivering the backup once the pod runs")
+			setPodRunning(ctx, &pod)
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+			Expect(imported(namespace, "20211201pod__1")).To(Equal("backup"))
+			Expect(ran(namespace, "CREATE DATABASE IF NOT EXISTS `20211201pod__1`")).To(BeTrue())
+
+			By("recording the lifecycle events")
+			Eventually(func() []string { return eventReasons(ctx, claim) }, timeout, interval).Should(ContainElements(
+				EventReasonPodCreated,
+				EventReasonWaitingForPod,
+				EventReasonPodReady,
+				EventReasonTransferStarted,
+				EventReasonTransferFinished,
+				EventReasonImportStarted,
+				EventReasonImportFinished,
+				EventReasonCleanedUp,
+			))
+		})
+
+		It("fails with the error of mysql when the import fails", func() {
+			key := "2021/12/01/badimport__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			fakePods.Stub("mysql --database=20211201badimport__1", podfake.Result{
+				ExitCode: 1,
+				Stderr:   "ERROR 1064 (42000) at line 12: You have an error in your SQL syntax\n",
+			})
+			claim := newClaim("badimport", key, backupsv1beta1.BackupClaimDestinationSpec{
+				Pod: backupsv1beta1.BackupClaimNewPodDestinationSpec{NamePrefix: "badimport"},
+			})
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			var pod corev1.Pod
+			Eventually(claimPod(ctx, claim, &pod), timeout, interval).Should(Succeed())
+			setPodRunning(ctx, &pod)
+
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusFailedToResolveDestination))
+			var current backupsv1beta1.BackupClaim
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &current)).To(Succeed())
+			Expect(current.Status.Error).To(ContainSubstring("exited with code 1"))
+			Expect(current.Status.Error).To(ContainSubstring("ERROR 1064 (42000)"))
+			Eventually(func() []string { return eventReasons(ctx, claim) }, timeout, interval).Should(ContainElement(EventReasonImportFailed))
+		})
+	})
+
+	Context("with an existing pod destination", func() {
+		It("delivers the backup to the pod", func() {
+			key := "2021/12/01/existing__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			pod := createRunningPod("database")
+			claim := newClaim("existing", key, existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+			data, ok := fakePods.ReadFile(namespace, pod.Name, "mysql", "/tmp/"+bucket+"/"+key)
+			Expect(ok).To(BeTrue())
+			Expect(string(data)).To(Equal("backup"))
+		})
+
+		It("does not deliver the backup again once ready", func() {
+			key := "2021/12/01/idempotent__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			pod := createRunningPod("database")
+			claim := newClaim("idempotent", key, existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+
+			touch(claim)
+			Consistently(func() string {
+				data, _ := fakePods.ReadFile(namespace, pod.Name, "mysql", "/tmp/"+bucket+"/"+key)
+				return string(data)
+			}, time.Second*2, interval).Should(Equal("backup"))
+			Expect(claimStatus(claim)()).To(Equal(backupsv1beta1.StatusReady))
+		})
+
+		It("waits for the backup to be restored from glacier", func() {
+			key := "2021/12/01/glacier__1.sql.xz"
+			fakeS3.AddVersion(bucket, key, s3fake.Version{Data: []byte("backup"), StorageClass: s3.StorageClassGlacier})
+			pod := createRunningPod("database")
+			claim := newClaim("glacier", key, existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			By("requesting a restore")
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReconciling))
+			Eventually(func() []string { return fakeS3.Requests() }, timeout, interval).Should(ContainElement("RestoreObject"))
+
+			By("waiting while the restore is in progress")
+			touch(claim)
+			Consistently(claimStatus(claim), time.Second*2, interval).Should(Equal(backupsv1beta1.StatusReconciling))
+			_, ok := fakePods.ReadFile(namespace, pod.Name, "mysql", "/tmp/"+bucket+"/"+key)
+			Expect(ok).To(BeFalse())
+
+			By("delivering the backup once restored")
+			fakeS3.CompleteRestore(bucket, key)
+			touch(claim)
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+			data, _ := fakePods.ReadFile(namespace, pod.Name, "mysql", "/tmp/"+bucket+"/"+key)
+			Expect(string(data)).To(Equal("backup"))
+			Eventually(func() []string { return eventReasons(ctx, claim) }, timeout, interval).Should(ContainElements(
+				EventReasonGlacierRestoreRequested,
+				EventReasonWaitingForGlacier,
+				EventReasonGlacierRestoreCompleted,
+			))
+		})
+
+		It("fails when the pod does not exist", func() {
+			key := "2021/12/01/missingpod__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			claim := newClaim("missingpod", key, existingPod("missing"))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusFailedToResolveDestination))
+		})
+
+		It("fails when the backup does not exist", func() {
+			pod := createRunningPod("database")
+			claim := newClaim("missingbackup", "2021/12/01/missing.sql.xz", existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusFailedToResolveSource))
+		})
+
+		It("fails when the backup can not be written to the pod", func() {
+			key := "2021/12/01/writefailure__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			fakePods.FailWrites(errors.New("connection reset"))
+			pod := createRunningPod("database")
+			claim := newClaim("writefailure", key, existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusFailedToResolveDestination))
+			var current backupsv1beta1.BackupClaim
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &current)).To(Succeed())
+			Expect(current.Status.Error).To(ContainSubstring("connection reset"))
+		})
+	})
+
+	Context("with a PVC destination", func() {
+		It("replaces the loader pod when it fails", func() {
+			key := "2021/12/01/pvc__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			claim := newClaim("volume", key, backupsv1beta1.BackupClaimDestinationSpec{
+				PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "volume-data"},
+			})
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+
+			By("creating the loader pod")
+			loader := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "volume-loader"}}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKeyFromObject(loader), loader)
+			}, timeout, interval).Should(Succeed())
+			failed := loader.UID
+
+			By("creating a new loader pod once it failed")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(loader), loader); err != nil {
+					return err
+				}
+				loader.Status.Phase = corev1.PodFailed
+				return k8sClient.Status().Update(ctx, loader)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() bool {
+				var current corev1.Pod
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(loader), &current)
+				return err == nil && current.UID != failed
+			}, timeout, interval).Should(BeTrue())
+			Eventually(func() []string { return eventReasons(ctx, claim) }, timeout, interval).Should(ContainElement(EventReasonPodReplaced))
+
+			By("delivering the backup once the new loader pod runs")
+			setPodRunning(ctx, loader)
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+			data, ok := fakePods.ReadFile(namespace, "volume-loader", "loader", "/data/"+strings.TrimSuffix(key, ".xz"))
+			Expect(ok).To(BeTrue())
+			Expect(string(data)).To(Equal("backup"))
+		})
+	})
+
+	Context("when the claim is deleted", func() {
+		It("stops reconciling it", func() {
+			key := "2021/12/01/deleted__1.sql.xz"
+			fakeS3.AddObject(bucket, key, []byte("backup"))
+			pod := createRunningPod("database")
+			claim := newClaim("deleted", key, existingPod(pod.Name))
+			Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+			Eventually(claimStatus(claim), timeout, interval).Should(Equal(backupsv1beta1.StatusReady))
+
+			Expect(k8sClient.Delete(ctx, claim)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &backupsv1beta1.BackupClaim{})
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			commands := len(commandsIn(namespace))
+			Consistently(func() int { return len(commandsIn(namespace)) }, time.Second*2, interval).Should(Equal(commands))
+		})
+	})
+})
+
+// claimPod gets the pod created for claim, once recorded in its status
+func claimPod(ctx context.Context, claim *backupsv1beta1.BackupClaim, pod *corev1.Pod) func() error {
+	return func() error {
+		var current backupsv1beta1.BackupClaim
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(claim), &current); err != nil {
+			return err
+		}
+		if current.Status.PodName == "" {
+			return errors.New("no pod recorded yet")
+		}
+		return k8sClient.Get(ctx, client.ObjectKey{Namespace: current.Namespace, Name: current.Status.PodName}, pod)
+	}
+}
+
+// setPodRunning fakes the kubelet, which envtest does not run
+func setPodRunning(ctx context.Context, pod *corev1.Pod) {
+	Eventually(func() error {
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+			return err
+		}
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.ContainerStatuses = nil
+		for _, container := range pod.Spec.Containers {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+				Name:  container.Name,
+				Image: container.Image,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				Ready: true,
+			})
+		}
+		return k8sClient.Status().Update(ctx, pod)
+	}, timeout, interval).Should(Succeed())
+}
+
+// eventReasons returns the reasons of the events recorded on claim
+func eventReasons(ctx context.Context, claim *backupsv1beta1.BackupClaim) []string {
+	var events corev1.EventList
+	if err := k8sClient.List(ctx, &events, client.InNamespace(claim.Namespace)); err != nil {
+		return nil
+	}
+	var reasons []string
+	for _, event := range events.Items {
+		if event.InvolvedObject.Kind == "BackupClaim" && event.InvolvedObject.Name == claim.Name {
+			reasons = append(reasons, event.Reason)
+		}
+	}
+	return reasons
+}
+
+// commandsIn returns the commands run in the pods of namespace
+func commandsIn(namespace string) []podfake.Command {
+	var commands []podfake.Command
+	for _, command := range fakePods.Commands() {
+		if command.Namespace == namespace {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// ran tells if a command containing match was run in a pod of namespace
+func ran(namespace, match string) bool {
+	for _, command := range commandsIn(namespace) {
+		if strings.Contains(command.String(), match) {
+			return true
+		}
+	}
+	return false
+}
+
+// imported returns what mysql read when importing database in a pod of
+// namespace
+func imported(namespace, database string) string {
+	for _, command := range commandsIn(namespace) {
+		if command.String() == "mysql --database="+database {
+			return string(command.Stdin)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestConcurrentReconciles reconciles claims of many namespaces at the same
+// time. Run with -race to catch state shared between reconciles.
+func TestConcurrentReconciles(t *testing.T) {
+	const claims = 20
+
+	var objects []client.Object
+	for i := 0; i < claims; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		key := fmt.Sprintf("2021/12/01/team%d__1.sql.xz", i)
+		claim := newTestClaim(namespace, "claim", key, existingPodDestination(namespace, "database"))
+		claim.UID = types.UID(namespace)
+		objects = append(objects, newTestPod(namespace, "database", nil), claim)
+	}
+	r := newTestReconciler(t, objects...)
+	for i := 0; i < claims; i++ {
+		r.S3.AddObject(testBucket, fmt.Sprintf("2021/12/01/team%d__1.sql.xz", i), []byte(fmt.Sprintf("team-%d", i)))
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, claims)
+	for i := 0; i < claims; i++ {
+		wg.Add(1)
+		go func(namespace string) {
+			defer wg.Done()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "claim"}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				errs <- fmt.Errorf("%s: %v", namespace, err)
+			}
+		}(fmt.Sprintf("team-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for i := 0; i < claims; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		claim := r.claim(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "claim"}})
+		if claim.Status.Status != backupsv1beta1.StatusReady {
+			t.Errorf("claim of %s is '%s', expected it to be ready", namespace, claim.Status.Status)
+		}
+		// Each pod must have received the backup of its own claim
+		data, ok := r.Pods.ReadFile(namespace, "database", "mysql", "/tmp/"+testBucket+"/"+claim.Spec.Source.S3.Key)
+		if !ok || string(data) != namespace {
+			t.Errorf("pod of %s received '%s', expected '%s'", namespace, data, namespace)
+		}
+	}
+}
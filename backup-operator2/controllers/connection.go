@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/engine"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Labels the pod of a claim with the UID of the claim, for its service to
+// select it. Claim names may not fit in a label value.
+const claimLabel = "backups.nvanheuverzwijn.io/claim-uid"
+
+// ensureConnection creates the credentials secret and the service of the new
+// pod destination unless they exist, and reports them in the status
+func (r *BackupClaimReconciler) ensureConnection(ctx context.Context, cc *claimContext) error {
+	serviceName := pod.ServiceName(cc.claim.Name)
+	secretName := pod.CredentialsSecretName(cc.claim.Name)
+
+	var secret corev1.Secret
+	err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: secretName}, &secret)
+	if apierrors.IsNotFound(err) {
+		host := fmt.Sprintf("%s.%s.svc", serviceName, cc.claim.Namespace)
+		newSecret, err := pod.CreateCredentialsSecret(secretName, cc.claim.Namespace, host, databaseName(cc.claim))
+		if err != nil {
+			return fmt.Errorf("Could not create credentials secret: %s", err.Error())
+		}
+		if err := ctrl.SetControllerReference(cc.claim, &newSecret, r.Scheme); err != nil {
+			return fmt.Errorf("Could not create credentials secret: %s", err.Error())
+		}
+		if err := r.Create(ctx, &newSecret); err != nil {
+			return fmt.Errorf("Could not create credentials secret: %s", err.Error())
+		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonCredentialsCreated, "Created credentials secret %s/%s", newSecret.Namespace, newSecret.Name)
+	} else if err != nil {
+		return fmt.Errorf("Could not get credentials secret: %s", err.Error())
+	} else if !metav1.IsControlledBy(&secret, cc.claim) {
+		// Never hand out the credentials of someone else
+		return fmt.Errorf("Secret '%s' already exists and does not belong to the claim", secretName)
+	}
+
+	var service corev1.Service
+	err = r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: serviceName}, &service)
+	if apierrors.IsNotFound(err) {
+		newService := pod.CreateServiceSpec(serviceName, cc.claim.Namespace, claimSelector(cc.claim))
+		if err := ctrl.SetControllerReference(cc.claim, &newService, r.Scheme); err != nil {
+			return fmt.Errorf("Could not create service: %s", err.Error())
+		}
+		if err := r.Create(ctx, &newService); err != nil {
+			return fmt.Errorf("Could not create service: %s", err.Error())
+		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonServiceCreated, "Created service %s/%s", newService.Namespace, newService.Name)
+	} else if err != nil {
+		return fmt.Errorf("Could not get service: %s", err.Error())
+	} else if !metav1.IsControlledBy(&service, cc.claim) {
+		return fmt.Errorf("Service '%s' already exists and does not belong to the claim", serviceName)
+	} else if selector := claimSelector(cc.claim); !reflect.DeepEqual(service.Spec.Selector, selector) {
+		// Services created before the pods were labeled by UID
+		service.Spec.Selector = selector
+		if err := r.Update(ctx, &service); err != nil {
+			return fmt.Errorf("Could not update service: %s", err.Error())
+		}
+	}
+
+	cc.claim.Status.Connection = &backupsv1beta1.BackupClaimConnectionStatus{
+		ServiceName: serviceName,
+		SecretName:  secretName,
+	}
+	return nil
+}
+
+// claimSelector selects the pod of the claim
+func claimSelector(backupClaim *backupsv1beta1.BackupClaim) map[string]string {
+	return map[string]string{claimLabel: string(backupClaim.UID)}
+}
+
+// grantDatabase grants the user of the credentials secret all privileges on
+// database
+func (r *BackupClaimReconciler) grantDatabase(ctx context.Context, cc *claimContext, podExec pod.Executor, database string) error {
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: pod.CredentialsSecretName(cc.claim.Name)}, &secret); err != nil {
+		return fmt.Errorf("Could not get credentials secret: %s", err.Error())
+	}
+	username := string(secret.Data[pod.CredentialsUsernameKey])
+	quotedDatabase, err := engine.QuoteIdentifier(engine.MySQL, database)
+	if err != nil {
+		return fmt.Errorf("Could not grant privileges on database '%s': %v", database, err)
+	}
+	quotedUsername, err := engine.QuoteLiteral(engine.MySQL, username)
+	if err != nil {
+		return fmt.Errorf("Could not grant privileges on database '%s': %v", database, err)
+	}
+	grant := fmt.Sprintf("GRANT ALL PRIVILEGES ON %s.* TO %s@'%%'", quotedDatabase, quotedUsername)
+	if _, err := pod.MustSucceed(pod.NewCommand("mysql", "-e", grant).Run(ctx, podExec)); err != nil {
+		return fmt.Errorf("Could not grant privileges on database '%s' to '%s': %v", database, username, err)
+	}
+	return nil
+}
+
+// databaseName is the name of the database the backup is imported in, the
+// key without extensions and without the characters other than letters,
+// digits and underscores. Keys without any are imported in the "backup"
+// database.
+func databaseName(backupClaim *backupsv1beta1.BackupClaim) string {
+	name := deliveredKey(backupClaim)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	// MySQL is the only engine of new pods
+	name, _ = engine.SanitizeIdentifier(engine.MySQL, name)
+	if name == "" {
+		return "backup"
+	}
+	return name
+}
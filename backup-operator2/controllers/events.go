@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of the events recorded on backup claims
+const (
+	EventReasonDeniedByPolicy             = "DeniedByPolicy"
+	EventReasonPodCreated                 = "PodCreated"
+	EventReasonWaitingForPod              = "WaitingForPod"
+	EventReasonPodReady                   = "PodReady"
+	EventReasonFailedToResolveDestination = "FailedToResolveDestination"
+	EventReasonFailedToResolveSource      = "FailedToResolveSource"
+	EventReasonGlacierRestoreRequested    = "GlacierRestoreRequested"
+	EventReasonWaitingForGlacier          = "WaitingForGlacierRestore"
+	EventReasonGlacierRestoreCompleted    = "GlacierRestoreCompleted"
+	EventReasonTransferStarted            = "TransferStarted"
+	EventReasonTransferFinished           = "TransferFinished"
+	EventReasonTransferFailed             = "TransferFailed"
+	EventReasonImportStarted              = "ImportStarted"
+	EventReasonImportFinished             = "ImportFinished"
+	EventReasonImportFailed               = "ImportFailed"
+	EventReasonCleanedUp                  = "CleanedUp"
+	EventReasonTimedOut                   = "TimedOut"
+	EventReasonPVCCreated                 = "PVCCreated"
+	EventReasonVolumeReady                = "VolumeReady"
+	EventReasonVolumeCloned               = "VolumeCloned"
+	EventReasonSnapshotCreated            = "SnapshotCreated"
+	EventReasonSnapshotFailed             = "SnapshotFailed"
+	EventReasonServiceCreated             = "ServiceCreated"
+	EventReasonCredentialsCreated         = "CredentialsCreated"
+	EventReasonBackupLost                 = "BackupLost"
+	EventReasonPodReplaced                = "PodReplaced"
+	EventReasonDegraded                   = "Degraded"
+
+	// Repeated events are recorded at most once per interval and claim
+	defaultEventThrottleInterval = 5 * time.Minute
+)
+
+// eventThrottle remembers when a repeated event was last recorded for a claim
+type eventThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     map[string]time.Time
+	now      func() time.Time
+}
+
+func newEventThrottle(interval time.Duration) *eventThrottle {
+	return &eventThrottle{
+		interval: interval,
+		last:     make(map[string]time.Time),
+		now:      time.Now,
+	}
+}
+
+// Allow tells if the event can be recorded, and remembers it was if so.
+// Events are told apart by their reason and message.
+func (t *eventThrottle) Allow(obj client.Object, reason, message string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := fmt.Sprintf("%s/%s/%s", client.ObjectKeyFromObject(obj), reason, message)
+	now := t.now()
+	if last, ok := t.last[key]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+	t.last[key] = now
+	return true
+}
+
+// Forget drops the repeated events of the claim, so they are recorded again
+// next time. Claims are forgotten by key, once deleted.
+func (t *eventThrottle) Forget(key client.ObjectKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prefix := fmt.Sprintf("%s/", key)
+	for key := range t.last {
+		if strings.HasPrefix(key, prefix) {
+			delete(t.last, key)
+		}
+	}
+}
+
+// recordEvent records an event on the claim, if a recorder is configured
+func (r *BackupClaimReconciler) recordEvent(obj runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(obj, eventtype, reason, messageFmt, args...)
+}
+
+// recordFailure records a warning event on the claim and counts the failure
+func (r *BackupClaimReconciler) recordFailure(obj client.Object, reason, messageFmt string, args ...interface{}) {
+	failures.WithLabelValues(obj.GetNamespace(), reason).Inc()
+	r.recordEvent(obj, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// recordRepeatedEvent records an event repeated by each reconcile until the
+// claim is ready, throttled so `kubectl describe` stays readable while the
+// claim waits or retries for hours
+func (r *BackupClaimReconciler) recordRepeatedEvent(obj client.Object, reason, messageFmt string, args ...interface{}) {
+	if !r.eventThrottle().Allow(obj, reason, fmt.Sprintf(messageFmt, args...)) {
+		return
+	}
+	r.recordEvent(obj, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+func (r *BackupClaimReconciler) eventThrottle() *eventThrottle {
+	r.throttleOnce.Do(func() {
+		if r.throttle == nil {
+			r.throttle = newEventThrottle(defaultEventThrottleInterval)
+		}
+	})
+	return r.throttle
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	podfake "github.com/nvanheuverzwijn/backup-operator/pkg/pod/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestEventThrottle(t *testing.T) {
+	now := time.Now()
+	throttle := newEventThrottle(time.Minute)
+	throttle.now = func() time.Time { return now }
+	claim := &backupsv1beta1.BackupClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "claim"}}
+	other := &backupsv1beta1.BackupClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "other"}}
+	const message = "Waiting for the destination pod to be ready"
+
+	if !throttle.Allow(claim, EventReasonWaitingForPod, message) {
+		t.Fatalf("first event should be allowed")
+	}
+	if throttle.Allow(claim, EventReasonWaitingForPod, message) {
+		t.Fatalf("repeated event should be throttled")
+	}
+	if !throttle.Allow(claim, EventReasonWaitingForGlacier, message) {
+		t.Fatalf("events with another reason should be allowed")
+	}
+	if !throttle.Allow(claim, EventReasonWaitingForPod, "Waiting for the loader pod to be running") {
+		t.Fatalf("events with another message should be allowed")
+	}
+	if !throttle.Allow(other, EventReasonWaitingForPod, message) {
+		t.Fatalf("events of another claim should be allowed")
+	}
+
+	now = now.Add(time.Minute)
+	if !throttle.Allow(claim, EventReasonWaitingForPod, message) {
+		t.Fatalf("event should be allowed once the interval elapsed")
+	}
+
+	throttle.Allow(other, EventReasonWaitingForPod, message)
+	throttle.Forget(client.ObjectKeyFromObject(claim))
+	if !throttle.Allow(claim, EventReasonWaitingForPod, message) {
+		t.Fatalf("event should be allowed once forgotten")
+	}
+	if throttle.Allow(other, EventReasonWaitingForPod, message) {
+		t.Fatalf("events of other claims should not be forgotten")
+	}
+}
+
+func TestRepeatedEvents(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, newPodDestination("app"))
+	r := newTestReconciler(t, claim, newTestPod("team", "app-backupclaim", claim))
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	r.Pods.Stub("mysql --database=", podfake.Result{ExitCode: 1, Stderr: "ERROR 1064 (42000) at line 1"})
+	req := requestFor(claim)
+	podReady := func() int {
+		count := 0
+		for {
+			select {
+			case event := <-recorder.Events:
+				if strings.Contains(event, EventReasonPodReady) {
+					count++
+				}
+			default:
+				return count
+			}
+		}
+	}
+
+	// Failing imports are retried, the pod is only reported ready once
+	for i := 0; i < 3; i++ {
+		if _, err := r.Reconcile(ctx, req); err == nil {
+			t.Fatal("the import should fail")
+		}
+	}
+	if count := podReady(); count != 1 {
+		t.Errorf("the ready pod should be reported once, got %d events", count)
+	}
+
+	// Claims created again under the same name report it again
+	if err := r.Delete(ctx, r.claim(req)); err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(req)
+	claim = newTestClaim("team", "app", key, newPodDestination("app"))
+	if err := r.Create(ctx, claim); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Delete(ctx, newTestPod("team", "app-backupclaim", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Create(ctx, newTestPod("team", "app-backupclaim", claim)); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = r.Reconcile(ctx, req)
+	if count := podReady(); count != 1 {
+		t.Errorf("the ready pod of the new claim should be reported, got %d events", count)
+	}
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// existingPodsSelector returns the selector of the pods of the workload or
+// selector of spec
+func (r *BackupClaimReconciler) existingPodsSelector(ctx context.Context, spec backupsv1beta1.BackupClaimExistingPodDestinationSpec) (labels.Selector, error) {
+	selector := spec.Selector
+	if ref := spec.WorkloadRef; ref != nil {
+		key := client.ObjectKey{Namespace: spec.Namespace, Name: ref.Name}
+		switch ref.Kind {
+		case "Deployment":
+			var deployment appsv1.Deployment
+			if err := r.Get(ctx, key, &deployment); err != nil {
+				return nil, fmt.Errorf("Could not get deployment '%s': %s", ref.Name, err.Error())
+			}
+			selector = deployment.Spec.Selector
+		case "StatefulSet":
+			var statefulSet appsv1.StatefulSet
+			if err := r.Get(ctx, key, &statefulSet); err != nil {
+				return nil, fmt.Errorf("Could not get statefulset '%s': %s", ref.Name, err.Error())
+			}
+			selector = statefulSet.Spec.Selector
+		case "ReplicaSet":
+			var replicaSet appsv1.ReplicaSet
+			if err := r.Get(ctx, key, &replicaSet); err != nil {
+				return nil, fmt.Errorf("Could not get replicaset '%s': %s", ref.Name, err.Error())
+			}
+			selector = replicaSet.Spec.Selector
+		default:
+			return nil, fmt.Errorf("Unsupported workload kind '%s'", ref.Kind)
+		}
+	}
+	if selector == nil {
+		return nil, fmt.Errorf("Existing pod destination needs a name, a workload or a selector")
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid selector: %s", err.Error())
+	}
+	// An empty selector would match every pod of the namespace
+	if s.Empty() {
+		return nil, fmt.Errorf("Existing pod destination selector matches every pod")
+	}
+	return s, nil
+}
+
+// selectExistingPods picks the pods receiving the backup among the pods of a
+// workload or selector, according to the policy of spec. It tells to wait
+// when the pods picked are not running yet.
+func selectExistingPods(spec backupsv1beta1.BackupClaimExistingPodDestinationSpec, pods []corev1.Pod) ([]corev1.Pod, bool, error) {
+	var candidates []corev1.Pod
+	for _, p := range pods {
+		if p.DeletionTimestamp == nil {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, true, fmt.Errorf("Could not find pod in namespace '%s' matching the destination", spec.Namespace)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		oi, iok := podOrdinal(candidates[i].Name)
+		oj, jok := podOrdinal(candidates[j].Name)
+		if iok && jok && oi != oj {
+			return oi < oj
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	switch spec.Policy {
+	case backupsv1beta1.ExistingPodPolicyAll:
+		for _, p := range candidates {
+			if p.Status.Phase != corev1.PodRunning {
+				return nil, true, nil
+			}
+		}
+		return candidates, false, nil
+	case backupsv1beta1.ExistingPodPolicyOrdinal:
+		if spec.Ordinal == nil {
+			return nil, true, fmt.Errorf("The Ordinal policy needs an ordinal")
+		}
+		var picked *corev1.Pod
+		if spec.WorkloadRef != nil && spec.WorkloadRef.Kind == "StatefulSet" {
+			for i := range candidates {
+				if ordinal, ok := podOrdinal(candidates[i].Name); ok && ordinal == int(*spec.Ordinal) {
+					picked = &candidates[i]
+					break
+				}
+			}
+		} else if int(*spec.Ordinal) < len(candidates) {
+			// Other pods have no ordinal, use their index once sorted
+			picked = &candidates[*spec.Ordinal]
+		}
+		if picked == nil {
+			return nil, true, fmt.Errorf("Could not find pod of ordinal %d in namespace '%s'", *spec.Ordinal, spec.Namespace)
+		}
+		if picked.Status.Phase != corev1.PodRunning {
+			return nil, true, nil
+		}
+		return []corev1.Pod{*picked}, false, nil
+	default:
+		for _, p := range candidates {
+			if p.Status.Phase == corev1.PodRunning && podReady(&p) {
+				return []corev1.Pod{p}, false, nil
+			}
+		}
+		return nil, true, nil
+	}
+}
+
+// podOrdinal returns the ordinal of a pod of a StatefulSet, the number
+// ending its name
+func podOrdinal(name string) (int, bool) {
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(name[i+1:])
+	return ordinal, err == nil
+}
+
+func podReady(p *corev1.Pod) bool {
+	for _, condition := range p.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// checkExistingPod makes sure the container of p receiving the backup
+// exists and can take it, and tells to wait until it is ready
+func checkExistingPod(backupClaim *backupsv1beta1.BackupClaim, p *corev1.Pod) (bool, error) {
+	container, err := existingPodContainer(p, backupClaim.Spec.Destination.ExistingPod.Container)
+	if err != nil {
+		return true, err
+	}
+	if _, err := existingPodPath(backupClaim, container); err != nil {
+		return true, err
+	}
+	return p.Status.Phase != corev1.PodRunning || !containerReady(p, container.Name), nil
+}
+
+// existingPodContainer returns the container of p named name, or its first
+// container when name is empty
+func existingPodContainer(p *corev1.Pod, name string) (*corev1.Container, error) {
+	for i := range p.Spec.Containers {
+		if name == "" || p.Spec.Containers[i].Name == name {
+			return &p.Spec.Containers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Pod '%s' has no container '%s'", p.Name, name)
+}
+
+// existingPodPath returns the path of the backup in container. Paths are
+// relative to the volume mount of the destination, and stay in it.
+func existingPodPath(backupClaim *backupsv1beta1.BackupClaim, container *corev1.Container) (string, error) {
+	spec := backupClaim.Spec.Destination.ExistingPod
+	var mountPath string
+	if spec.VolumeMount != "" {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name != spec.VolumeMount {
+				continue
+			}
+			if mount.ReadOnly {
+				return "", fmt.Errorf("Volume mount '%s' of container '%s' is read only", mount.Name, container.Name)
+			}
+			mountPath = mount.MountPath
+		}
+		if mountPath == "" {
+			return "", fmt.Errorf("Container '%s' has no volume mount '%s'", container.Name, spec.VolumeMount)
+		}
+	}
+
+	var path string
+	switch {
+	case spec.Path != "":
+		path = spec.Path
+	case spec.Directory != "":
+		path = filepath.Join(spec.Directory, deliveredKey(backupClaim))
+	case mountPath != "":
+		path = deliveredKey(backupClaim)
+	default:
+		return fmt.Sprintf("/tmp/%s/%s", backupClaim.Spec.Source.S3.BucketName, deliveredKey(backupClaim)), nil
+	}
+	if mountPath == "" {
+		if !filepath.IsAbs(path) {
+			return "", fmt.Errorf("Path '%s' must be absolute without a volume mount", path)
+		}
+		return filepath.Clean(path), nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(mountPath, path)
+	}
+	path = filepath.Clean(path)
+	if rel, err := filepath.Rel(mountPath, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("Path '%s' is not in volume mount '%s'", path, spec.VolumeMount)
+	}
+	return path, nil
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newWorkloadPod(name string, phase corev1.PodPhase, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: name, Labels: map[string]string{"app": "db"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "sidecar", Image: "envoy"},
+			{
+				Name:  "mysql",
+				Image: "mysql",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "data", MountPath: "/var/lib/mysql"},
+					{Name: "config", MountPath: "/etc/mysql", ReadOnly: true},
+				},
+			},
+		}},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+	if phase == corev1.PodRunning {
+		setReady(p)
+	}
+	return p
+}
+
+func TestSelectExistingPods(t *testing.T) {
+	pods := []corev1.Pod{
+		*newWorkloadPod("db-10", corev1.PodRunning, true),
+		*newWorkloadPod("db-2", corev1.PodPending, false),
+		*newWorkloadPod("db-1", corev1.PodRunning, true),
+		*newWorkloadPod("db-0", corev1.PodRunning, false),
+	}
+	statefulSet := &backupsv1beta1.BackupClaimWorkloadReference{Kind: "StatefulSet", Name: "db"}
+	ordinal := func(i int32) *int32 { return &i }
+
+	for _, test := range []struct {
+		name   string
+		spec   backupsv1beta1.BackupClaimExistingPodDestinationSpec
+		picked []string
+		wait   bool
+		err    bool
+	}{
+		{name: "first ready", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{WorkloadRef: statefulSet}, picked: []string{"db-1"}},
+		{name: "all waits for pending pods", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{WorkloadRef: statefulSet, Policy: backupsv1beta1.ExistingPodPolicyAll}, wait: true},
+		{name: "ordinal", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{WorkloadRef: statefulSet, Policy: backupsv1beta1.ExistingPodPolicyOrdinal, Ordinal: ordinal(10)}, picked: []string{"db-10"}},
+		{name: "ordinal waits for its pod", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{WorkloadRef: statefulSet, Policy: backupsv1beta1.ExistingPodPolicyOrdinal, Ordinal: ordinal(2)}, wait: true},
+		{name: "missing ordinal", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{WorkloadRef: statefulSet, Policy: backupsv1beta1.ExistingPodPolicyOrdinal, Ordinal: ordinal(3)}, wait: true, err: true},
+		{name: "index of selected pods", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Policy: backupsv1beta1.ExistingPodPolicyOrdinal, Ordinal: ordinal(3)}, picked: []string{"db-10"}},
+	} {
+		picked, wait, err := selectExistingPods(test.spec, pods)
+		var names []string
+		for _, p := range picked {
+			names = append(names, p.Name)
+		}
+		if wait != test.wait || (err != nil) != test.err || len(names) != len(test.picked) || (len(names) != 0 && names[0] != test.picked[0]) {
+			t.Errorf("%s: expected %v (wait %t, error %t), got %v (wait %t, %v)", test.name, test.picked, test.wait, test.err, names, wait, err)
+		}
+	}
+
+	if _, _, err := selectExistingPods(backupsv1beta1.BackupClaimExistingPodDestinationSpec{}, nil); err == nil {
+		t.Error("selecting among no pods should fail")
+	}
+}
+
+func TestExistingPodWorkload(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	ctx := context.Background()
+	claim := newTestClaim("db", "app", key, backupsv1beta1.BackupClaimDestinationSpec{
+		ExistingPod: backupsv1beta1.BackupClaimExistingPodDestinationSpec{
+			Namespace:   "db",
+			WorkloadRef: &backupsv1beta1.BackupClaimWorkloadReference{Kind: "StatefulSet", Name: "db"},
+			Container:   "mysql",
+			Policy:      backupsv1beta1.ExistingPodPolicyAll,
+			Engine:      "mysql",
+		},
+	})
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: "db"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		},
+	}
+	other := newWorkloadPod("web-0", corev1.PodRunning, true)
+	other.Labels = map[string]string{"app": "web"}
+	r := newTestReconciler(t,
+		claim, statefulSet, other,
+		newWorkloadPod("db-0", corev1.PodRunning, true),
+		newWorkloadPod("db-1", corev1.PodRunning, false),
+	)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	req := requestFor(claim)
+	r.reconcile(req)
+
+	for _, name := range []string{"db-0", "db-1"} {
+		if data, ok := r.Pods.ReadFile("db", name, "mysql", "/tmp/"+testBucket+"/"+key); !ok || string(data) != "backup" {
+			t.Errorf("%s should receive the backup in its mysql container, got '%s'", name, data)
+		}
+	}
+	if _, ok := r.Pods.ReadFile("db", "web-0", "mysql", "/tmp/"+testBucket+"/"+key); ok {
+		t.Error("pods outside of the workload should not receive the backup")
+	}
+	probed := map[string]bool{}
+	for _, command := range r.Pods.Commands() {
+		if command.String() == "mysqladmin ping -h 127.0.0.1 --protocol=TCP --silent" && command.ContainerName == "mysql" {
+			probed[command.PodName] = true
+		}
+	}
+	if !probed["db-0"] || !probed["db-1"] {
+		t.Errorf("the database of every pod should be probed, ran %v", r.Pods.Commands())
+	}
+	claim = r.claim(req)
+	if claim.Status.Status != backupsv1beta1.StatusReady {
+		t.Errorf("the claim should be ready, got '%s' %s", claim.Status.Status, claim.Status.Error)
+	}
+
+	// A container missing from the pods fails to resolve
+	claim.Spec.Destination.ExistingPod.Container = "postgres"
+	if err := r.Update(ctx, claim); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Error("a missing container should fail")
+	}
+
+	// Waits for the container to be ready, even once the pod is running
+	restarting := newWorkloadPod("db-0", corev1.PodRunning, true)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "db", Name: "db-0"}, restarting); err != nil {
+		t.Fatal(err)
+	}
+	restarting.Status.ContainerStatuses[1].State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	restarting.Status.ContainerStatuses[1].Ready = false
+	if err := r.Status().Update(ctx, restarting); err != nil {
+		t.Fatal(err)
+	}
+	claim = r.claim(req)
+	claim.Spec.Destination.ExistingPod = backupsv1beta1.BackupClaimExistingPodDestinationSpec{
+		Namespace:   "db",
+		Name:        "db-0",
+		Container:   "mysql",
+		VolumeMount: "data",
+	}
+	if err := r.Update(ctx, claim); err != nil {
+		t.Fatal(err)
+	}
+	r.Pods.Reset()
+	r.reconcile(req)
+	if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusReconciling || len(r.Pods.Commands()) != 0 {
+		t.Errorf("the claim should wait for the container, got '%s' after %v", claim.Status.Status, r.Pods.Commands())
+	}
+}
+
+func TestExistingPodPath(t *testing.T) {
+	container := &newWorkloadPod("db-0", corev1.PodRunning, true).Spec.Containers[1]
+	for _, test := range []struct {
+		name string
+		spec backupsv1beta1.BackupClaimExistingPodDestinationSpec
+		path string
+	}{
+		{name: "default", path: "/tmp/backups/2021/app.sql.xz"},
+		{name: "directory", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Directory: "/restore"}, path: "/restore/2021/app.sql.xz"},
+		{name: "path", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Path: "/restore/app.sql.xz", Directory: "/ignored"}, path: "/restore/app.sql.xz"},
+		{name: "volume mount", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "data"}, path: "/var/lib/mysql/2021/app.sql.xz"},
+		{name: "relative directory", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "data", Directory: "restore"}, path: "/var/lib/mysql/restore/2021/app.sql.xz"},
+		{name: "absolute path in mount", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "data", Path: "/var/lib/mysql/app.sql.xz"}, path: "/var/lib/mysql/app.sql.xz"},
+		{name: "relative path", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Path: "app.sql.xz"}},
+		{name: "path out of mount", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "data", Path: "../app.sql.xz"}},
+		{name: "missing mount", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "logs"}},
+		{name: "read only mount", spec: backupsv1beta1.BackupClaimExistingPodDestinationSpec{VolumeMount: "config"}},
+	} {
+		claim := &backupsv1beta1.BackupClaim{Spec: backupsv1beta1.BackupClaimSpec{
+			Source: backupsv1beta1.BackupClaimSourceSpec{
+				S3: backupsv1beta1.BackupClaimS3SourceSpec{BucketName: "backups", Key: "2021/app.sql.xz"},
+			},
+			Destination: backupsv1beta1.BackupClaimDestinationSpec{ExistingPod: test.spec},
+		}}
+		path, err := existingPodPath(claim, container)
+		if path != test.path || (err == nil) != (test.path != "") {
+			t.Errorf("%s: expected '%s', got '%s' %v", test.name, test.path, path, err)
+		}
+	}
+}
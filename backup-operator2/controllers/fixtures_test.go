@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	podfake "github.com/nvanheuverzwijn/backup-operator/pkg/pod/fake"
+	s3fake "github.com/nvanheuverzwijn/backup-operator/pkg/source/fake"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// Bucket the claims of newTestClaim read their backup from
+const testBucket = "backups"
+
+// testReconciler reconciles claims against a fake API server, reading the
+// backups from S3 and running the pod commands in Pods
+type testReconciler struct {
+	*BackupClaimReconciler
+	S3   *s3fake.S3
+	Pods *podfake.Pods
+	t    *testing.T
+}
+
+// newTestReconciler returns a reconciler of a fake API server holding objs
+func newTestReconciler(t *testing.T, objs ...client.Object) *testReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		backupsv1beta1.AddToScheme,
+		corev1.AddToScheme,
+		appsv1.AddToScheme,
+		snapshotv1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s3 := s3fake.NewS3()
+	pods := podfake.NewPods()
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&backupsv1beta1.BackupClaim{}, &corev1.Pod{}, &snapshotv1.VolumeSnapshot{}).
+		WithIndex(&corev1.Pod{}, jobOwnerKey, podOwnerIndex).
+		WithIndex(&corev1.Pod{}, ".metadata.name", podNameIndex).
+		WithIndex(&backupsv1beta1.BackupClaim{}, deliveredPodKey, deliveredPodsIndex).
+		Build()
+	return &testReconciler{
+		BackupClaimReconciler: &BackupClaimReconciler{
+			Client:      c,
+			APIReader:   c,
+			Scheme:      scheme,
+			NewS3Client: func(roleARN, externalID, sessionName string) s3iface.S3API { return s3 },
+			NewExecutor: pods.Factory(),
+		},
+		S3:   s3,
+		Pods: pods,
+		t:    t,
+	}
+}
+
+// newTestClaim returns the claim namespace/name delivering key of testBucket
+// to destination. Its UID is its name.
+func newTestClaim(namespace, name, key string, destination backupsv1beta1.BackupClaimDestinationSpec) *backupsv1beta1.BackupClaim {
+	return &backupsv1beta1.BackupClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name)},
+		Spec: backupsv1beta1.BackupClaimSpec{
+			Source: backupsv1beta1.BackupClaimSourceSpec{
+				S3: backupsv1beta1.BackupClaimS3SourceSpec{BucketName: testBucket, Key: key},
+			},
+			Destination: destination,
+		},
+	}
+}
+
+// newPodDestination delivers to a new pod named after prefix
+func newPodDestination(prefix string) backupsv1beta1.BackupClaimDestinationSpec {
+	return backupsv1beta1.BackupClaimDestinationSpec{
+		Pod: backupsv1beta1.BackupClaimNewPodDestinationSpec{NamePrefix: prefix},
+	}
+}
+
+// existingPodDestination delivers to the pod namespace/name
+func existingPodDestination(namespace, name string) backupsv1beta1.BackupClaimDestinationSpec {
+	return backupsv1beta1.BackupClaimDestinationSpec{
+		ExistingPod: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Namespace: namespace, Name: name},
+	}
+}
+
+// newTestPod returns a running pod with a ready mysql container. The pod is
+// controlled by owner, unless nil. Its UID is its name.
+func newTestPod(namespace, name string, owner *backupsv1beta1.BackupClaim) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name)},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "mysql"}}},
+	}
+	if owner != nil {
+		p.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, backupsv1beta1.GroupVersion.WithKind("BackupClaim")),
+		}
+	}
+	setReady(p)
+	return p
+}
+
+// requestFor returns the reconcile request of claim
+func requestFor(claim *backupsv1beta1.BackupClaim) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}}
+}
+
+// reconcile reconciles the claim of req and fails the test on errors
+func (r *testReconciler) reconcile(req ctrl.Request) ctrl.Result {
+	r.t.Helper()
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return result
+}
+
+// claim returns the current state of the claim of req
+func (r *testReconciler) claim(req ctrl.Request) *backupsv1beta1.BackupClaim {
+	r.t.Helper()
+	claim := &backupsv1beta1.BackupClaim{}
+	if err := r.Get(context.Background(), req.NamespacedName, claim); err != nil {
+		r.t.Fatal(err)
+	}
+	return claim
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+)
+
+func TestImportHostileKey(t *testing.T) {
+	for _, tc := range []struct {
+		key      string
+		database string
+	}{
+		{key: "2021/12/01/app`; DROP DATABASE mysql; -- $(reboot)'.sql.xz", database: "20211201appDROPDATABASEmysqlreboot"},
+		// Unquoted, the mysql client would split it at the delimiters
+		{key: "2021/12/01/x; DROP DATABASE y; .sql.xz", database: "20211201xDROPDATABASEy"},
+	} {
+		claim := newTestClaim("team", "app", tc.key, newPodDestination("app"))
+		r := newTestReconciler(t, claim, newTestPod("team", "app-backupclaim", claim))
+		r.S3.AddObject(testBucket, tc.key, []byte("backup"))
+		req := requestFor(claim)
+		r.reconcile(req)
+		if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusReady {
+			t.Fatalf("%s: the backup should be imported, got '%s' %s", tc.key, claim.Status.Status, claim.Status.Error)
+		}
+
+		created, imported := false, false
+		for _, command := range r.Pods.Commands() {
+			switch command.Command[0] {
+			case "bash", "sh":
+				t.Errorf("%s: no shell should be run, ran %v", tc.key, command)
+			case "mysql":
+				// Statements only hold sanitized identifiers, never the path
+				for i, arg := range command.Command {
+					if i > 0 && command.Command[i-1] == "-e" && (strings.Contains(arg, "/tmp/") || strings.Contains(arg, "DROP DATABASE")) {
+						t.Errorf("%s: the path should not reach the SQL parser, ran %v", tc.key, command)
+					}
+				}
+				created = created || command.String() == "mysql -e CREATE DATABASE IF NOT EXISTS `"+tc.database+"`"
+				imported = imported || (command.String() == "mysql --database="+tc.database && string(command.Stdin) == "backup")
+			}
+		}
+		if !created || !imported {
+			t.Errorf("%s: the backup should be streamed to the sanitized database %s, ran %v", tc.key, tc.database, r.Pods.Commands())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/source"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Extension of the manifest written next to the delivered backup
+const manifestExtension = ".manifest.json"
+
+// deliveryManifest records a delivery next to the delivered backup. Reconciles
+// compare it with the claim and its source to tell if the backup is already
+// there, without a shell in the destination container.
+type deliveryManifest struct {
+	ClaimUID types.UID `json:"claimUID"`
+	// URL of the source
+	Source string `json:"source"`
+	// Version of the source object, its ETag when the bucket is not
+	// versioned
+	Version string `json:"version"`
+	// sha256 of the delivered bytes, decrypted
+	Checksum    string      `json:"checksum"`
+	DeliveredAt metav1.Time `json:"deliveredAt"`
+}
+
+// manifestPath returns the path of the manifest of the backup delivered at path
+func manifestPath(path string) string {
+	return path + manifestExtension
+}
+
+// sourceVersion returns the version of s3file, or its ETag in buckets without
+// versioning
+func sourceVersion(s3file *source.S3File) string {
+	if version := s3file.VersionID(); version != "" && version != "null" {
+		return version
+	}
+	return s3file.ETag()
+}
+
+// newManifest returns the manifest of s3file delivered for the claim
+func newManifest(backupClaim *backupsv1beta1.BackupClaim, s3file *source.S3File, checksum string) deliveryManifest {
+	return deliveryManifest{
+		ClaimUID:    backupClaim.UID,
+		Source:      s3file.URL(),
+		Version:     sourceVersion(s3file),
+		Checksum:    checksum,
+		DeliveredAt: metav1.Now(),
+	}
+}
+
+// matches tells if m records the delivery of s3file for the claim
+func (m *deliveryManifest) matches(backupClaim *backupsv1beta1.BackupClaim, s3file *source.S3File) bool {
+	return m.ClaimUID == backupClaim.UID && m.Source == s3file.URL() && m.Version == sourceVersion(s3file)
+}
+
+// isDelivered tells if the backup at path is the delivery of s3file for the
+// claim, as recorded by its manifest
+func isDelivered(ctx context.Context, cc *claimContext, podExec pod.Executor, path string, s3file *source.S3File) (bool, error) {
+	manifest, err := readManifest(ctx, podExec, path)
+	if err != nil || manifest == nil || !manifest.matches(cc.claim, s3file) {
+		return false, err
+	}
+	// The backup may have been removed without its manifest
+	if _, err := podExec.File(ctx, path).Stat(); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("Could not check if '%s' exists: %v", path, err)
+	}
+	cc.logger.Info("Backup claim is already ready")
+	return true, nil
+}
+
+// readManifest returns the manifest of the backup delivered at path, or nil if
+// there is none. Unreadable manifests, left by an interrupted write, are
+// ignored to deliver the backup again.
+func readManifest(ctx context.Context, podExec pod.Executor, path string) (*deliveryManifest, error) {
+	f := podExec.File(ctx, manifestPath(path))
+	defer f.Close()
+	if _, err := f.Stat(); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read manifest '%s': %v", manifestPath(path), err)
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read manifest '%s': %v", manifestPath(path), err)
+	}
+	var m deliveryManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, nil
+	}
+	return &m, nil
+}
+
+// writeManifest replaces the manifest of the backup delivered at path
+func writeManifest(ctx context.Context, podExec pod.Executor, path string, m deliveryManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("Could not write manifest '%s': %v", manifestPath(path), err)
+	}
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"rm", "-f", manifestPath(path)})); err != nil {
+		return fmt.Errorf("Could not write manifest '%s': %v", manifestPath(path), err)
+	}
+	f := podExec.File(ctx, manifestPath(path))
+	defer f.Close()
+	// Files are appended to by each write, write it at once
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("Could not write manifest '%s': %v", manifestPath(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	const path = "/tmp/" + testBucket + "/" + key
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, existingPodDestination("db", "mysql-0"))
+	existingPod := newTestPod("db", "mysql-0", nil)
+	r := newTestReconciler(t, claim, existingPod)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	s3, pods := r.S3, r.Pods
+	req := requestFor(claim)
+	deliver := func() { r.reconcile(req) }
+	file := func() string {
+		data, _ := pods.ReadFile("db", "mysql-0", "mysql", path)
+		return string(data)
+	}
+	// The backup is removed with its manifest before being sent
+	sentSince := func(before int) bool {
+		for _, command := range pods.Commands()[before:] {
+			if command.String() == "rm -f "+path+" "+manifestPath(path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	deliver()
+	data, ok := pods.ReadFile("db", "mysql-0", "mysql", manifestPath(path))
+	if !ok {
+		t.Fatalf("the manifest should be written next to the backup, ran %v", pods.Commands())
+	}
+	var manifest deliveryManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.ClaimUID != "app" || manifest.Source != "s3://"+testBucket+"/"+key || manifest.Version == "" || manifest.DeliveredAt.IsZero() {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+	if checksum := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("backup"))); manifest.Checksum != checksum {
+		t.Errorf("expected checksum %s, got %s", checksum, manifest.Checksum)
+	}
+
+	// Delivered backups are not sent again
+	before := len(pods.Commands())
+	deliver()
+	if file() != "backup" || sentSince(before) {
+		t.Errorf("the backup should not be sent again, got '%s' after %v", file(), pods.Commands()[before:])
+	}
+
+	// New versions of the source are
+	s3.AddObject(testBucket, key, []byte("newer"))
+	deliver()
+	if file() != "newer" {
+		t.Errorf("the new version should replace the backup, got '%s'", file())
+	}
+
+	// And so are backups with a corrupted manifest, or without their data
+	for name, corrupt := range map[string]func(){
+		"corrupted manifest": func() {
+			f := pods.Factory()(existingPod, "mysql").File(ctx, manifestPath(path))
+			_, _ = f.Write([]byte("{"))
+		},
+		"deleted backup": func() {
+			_, _ = pods.Factory()(existingPod, "mysql").ExecCmd(ctx, []string{"rm", "-f", path})
+		},
+	} {
+		corrupt()
+		before := len(pods.Commands())
+		deliver()
+		if !sentSince(before) || file() != "newer" {
+			t.Errorf("%s: the backup should be sent again, got '%s' after %v", name, file(), pods.Commands()[before:])
+		}
+	}
+}
+
+func TestManifestImported(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	claim := newTestClaim("team", "app", key, newPodDestination("app"))
+	r := newTestReconciler(t, claim, newTestPod("team", "app-backupclaim", claim))
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	for i := 0; i < 2; i++ {
+		r.reconcile(requestFor(claim))
+	}
+	imports := 0
+	for _, command := range r.Pods.Commands() {
+		if command.Command[0] == "xz" {
+			imports++
+		}
+	}
+	if imports != 1 {
+		t.Errorf("the backup should be imported once, ran %v", r.Pods.Commands())
+	}
+	if _, ok := r.Pods.ReadFile("team", "app-backupclaim", "mysql", manifestPath("/tmp/"+testBucket+"/"+key)); !ok {
+		t.Errorf("the manifest should outlive the imported dump")
+	}
+}
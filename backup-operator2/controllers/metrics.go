@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "backupclaim"
+
+	// Phase of the claims without status yet
+	phasePending = "Pending"
+
+	sourceTypeS3 = "s3"
+	engineMySQL  = "mysql"
+)
+
+var (
+	transferBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "transfer_size_bytes",
+		Help:      "Size of the backups sent to the destinations.",
+		Buckets:   prometheus.ExponentialBuckets(1024*1024, 4, 10),
+	}, []string{"namespace", "source"})
+
+	transferDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "transfer_duration_seconds",
+		Help:      "Time taken to send the backups to the destinations.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"namespace", "source"})
+
+	transfersInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "transfers_in_flight",
+		Help:      "Number of backups being sent to the destinations.",
+	}, []string{"namespace"})
+
+	glacierWaitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "glacier_wait_seconds",
+		Help:      "Time between the request of a glacier restore and its completion.",
+		Buckets:   prometheus.ExponentialBuckets(60, 2, 12),
+	}, []string{"namespace"})
+
+	importDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "import_duration_seconds",
+		Help:      "Time taken to import the backups in the databases.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"namespace", "engine"})
+
+	failures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "failures_total",
+		Help:      "Number of failures, by reason.",
+	}, []string{"namespace", "reason"})
+
+	claimsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "claims"),
+		"Number of backup claims, by phase.",
+		[]string{"namespace", "phase"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		transferBytes,
+		transferDuration,
+		transfersInFlight,
+		glacierWaitDuration,
+		importDuration,
+		failures,
+	)
+}
+
+// claimsCollector counts the claims by phase from the cache when scraped, so
+// deleted claims don't linger in the metrics
+type claimsCollector struct {
+	client client.Reader
+}
+
+func (c *claimsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- claimsDesc
+}
+
+func (c *claimsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var claims backupsv1beta1.BackupClaimList
+	if err := c.client.List(ctx, &claims); err != nil {
+		ch <- prometheus.NewInvalidMetric(claimsDesc, err)
+		return
+	}
+
+	type key struct{ namespace, phase string }
+	counts := map[key]int{}
+	for _, claim := range claims.Items {
+		phase := claim.Status.Status
+		if phase == "" {
+			phase = phasePending
+		}
+		counts[key{claim.Namespace, phase}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(claimsDesc, prometheus.GaugeValue, float64(count), k.namespace, k.phase)
+	}
+}
+
+// registerClaimsCollector registers the collector of the claims, once
+func registerClaimsCollector(c client.Reader) error {
+	err := metrics.Registry.Register(&claimsCollector{client: c})
+	if are := (prometheus.AlreadyRegisteredError{}); errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
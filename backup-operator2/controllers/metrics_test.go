@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClaimsCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = backupsv1beta1.AddToScheme(scheme)
+	claim := func(namespace, name, status string) *backupsv1beta1.BackupClaim {
+		return &backupsv1beta1.BackupClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status:     backupsv1beta1.BackupClaimStatus{Status: status},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		claim("a", "ready-1", backupsv1beta1.StatusReady),
+		claim("a", "ready-2", backupsv1beta1.StatusReady),
+		claim("a", "new", ""),
+		claim("b", "failed", backupsv1beta1.StatusFailedToResolveSource),
+	).Build()
+
+	expected := `
+# HELP backupclaim_claims Number of backup claims, by phase.
+# TYPE backupclaim_claims gauge
+backupclaim_claims{namespace="a",phase="Pending"} 1
+backupclaim_claims{namespace="a",phase="Ready"} 2
+backupclaim_claims{namespace="b",phase="Failed to resolve source"} 1
+`
+	if err := testutil.CollectAndCompare(&claimsCollector{client: c}, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPolicyDenial(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	policy := &backupsv1beta1.BackupSourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team"},
+		Spec: backupsv1beta1.BackupSourcePolicySpec{
+			Namespaces: []string{"team"},
+			S3:         []backupsv1beta1.BackupSourcePolicyS3Spec{{BucketName: "other"}},
+		},
+	}
+	claim := newTestClaim("team", "app", key, newPodDestination("app"))
+	r := newTestReconciler(t, claim, policy)
+	req := requestFor(claim)
+
+	// The namespace can not be read, the claim is not denied but retried
+	if _, err := r.Reconcile(context.Background(), req); err == nil {
+		t.Errorf("failing to check the policies should be retried")
+	}
+	if status := r.claim(req).Status.Status; status == backupsv1beta1.StatusDeniedByPolicy {
+		t.Errorf("the claim should not be denied when the policies can not be checked")
+	}
+
+	if err := r.Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team"}}); err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(req)
+	if status := r.claim(req).Status.Status; status != backupsv1beta1.StatusDeniedByPolicy {
+		t.Fatalf("the claim should be denied, got '%s'", status)
+	}
+
+	// Policy changes reconcile the denied claims
+	other := newTestClaim("other", "ready", key, newPodDestination("ready"))
+	if err := r.Create(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+	other.Status.Status = backupsv1beta1.StatusReady
+	if err := r.Status().Update(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+	requests := r.claimsDeniedByPolicy(context.Background(), policy)
+	if len(requests) != 1 || requests[0] != req {
+		t.Errorf("only the denied claim should be reconciled, got %v", requests)
+	}
+}
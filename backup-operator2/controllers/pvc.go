@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HandleDestinationPVC creates the volume of the claim, sized from the
+// backup, unless it exists, then the loader pod writing the backup in it. It
+// returns the loader pod once it is running, or nil when the backup is
+// already in the volume.
+func (r *BackupClaimReconciler) HandleDestinationPVC(ctx context.Context, cc *claimContext, s3file *source.S3File) (*corev1.Pod, bool, error) {
+	spec := cc.claim.Spec.Destination.PVC
+	cc.logger.Info("Checking if persistent volume claim exists")
+	var pvc corev1.PersistentVolumeClaim
+	err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: spec.Name}, &pvc)
+	if apierrors.IsNotFound(err) {
+		cc.logger.Info("Persistent volume claim does not exist, creating it.")
+		newPVC := pod.CreatePVCSpec(spec, cc.claim.Namespace, deliveredKey(cc.claim), s3file.Size())
+		var snapshot *snapshotv1.VolumeSnapshot
+		if spec.Snapshot != nil {
+			if snapshot, err = r.findSnapshot(ctx, cc.claim.Namespace, s3file); err != nil {
+				return nil, true, err
+			}
+		}
+		if snapshot != nil {
+			cloneSnapshot(&newPVC, snapshot)
+		}
+		if err := ctrl.SetControllerReference(cc.claim, &newPVC, r.Scheme); err != nil {
+			return nil, true, fmt.Errorf("Could not create a new persistent volume claim: %s", err.Error())
+		}
+		if err := r.Create(ctx, &newPVC); err != nil {
+			return nil, true, fmt.Errorf("Could not create a new persistent volume claim: %s", err.Error())
+		}
+		size := newPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonPVCCreated, "Created persistent volume claim %s/%s of %s", newPVC.Namespace, newPVC.Name, size.String())
+		// A new volume is empty, whatever was delivered before
+		cc.claim.Status.Volume = nil
+
+		// The clone already holds the backup, no need for a loader
+		if snapshot != nil {
+			cc.claim.Status.Volume = &backupsv1beta1.BackupClaimVolumeStatus{
+				ClaimName:  newPVC.Name,
+				Path:       volumeBackupPath(cc.claim),
+				Version:    sourceVersion(s3file),
+				ClonedFrom: snapshot.Name,
+			}
+			r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonVolumeCloned, "Cloned persistent volume claim %s/%s from volume snapshot %s", newPVC.Namespace, newPVC.Name, snapshot.Name)
+			return nil, false, nil
+		}
+	} else if err != nil {
+		return nil, true, err
+	} else if !metav1.IsControlledBy(&pvc, cc.claim) {
+		return nil, true, fmt.Errorf("Persistent volume claim '%s' exists and was not created by the backup claim", spec.Name)
+	}
+
+	// The loader pod is removed once the manifest in the volume matches the
+	// source, it is only mounted again to check newer versions
+	if volume := cc.claim.Status.Volume; volume != nil && volume.ClaimName == spec.Name && volume.Version == sourceVersion(s3file) {
+		// Volumes are bound once mounted when the storage class waits for
+		// the first consumer
+		cc.claim.Status.Volume.VolumeName = pvc.Spec.VolumeName
+		return nil, false, nil
+	}
+
+	var loader corev1.Pod
+	name := pod.LoaderPodName(cc.claim.Name)
+	err = r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: name}, &loader)
+	if apierrors.IsNotFound(err) {
+		cc.logger.Info("Loader pod does not exist, creating it.")
+		newPod := pod.CreateLoaderPodSpec(spec, name, cc.claim.Namespace)
+		if err := ctrl.SetControllerReference(cc.claim, &newPod, r.Scheme); err != nil {
+			return nil, true, fmt.Errorf("Could not create a new loader pod: %s", err.Error())
+		}
+		if err := r.Create(ctx, &newPod); err != nil {
+			return nil, true, fmt.Errorf("Could not create a new loader pod: %s", err.Error())
+		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonPodCreated, "Created loader pod %s/%s", newPod.Namespace, newPod.Name)
+		return nil, true, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+
+	switch loader.Status.Phase {
+	case corev1.PodRunning:
+		return &loader, false, nil
+	case corev1.PodFailed, corev1.PodSucceeded:
+		// Loader pods never restart, replace them
+		if err := r.Delete(ctx, &loader); client.IgnoreNotFound(err) != nil {
+			return nil, true, fmt.Errorf("Could not delete loader pod: %s", err.Error())
+		}
+		r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonPodReplaced, "Deleted %s loader pod %s/%s", strings.ToLower(string(loader.Status.Phase)), loader.Namespace, loader.Name)
+		return nil, true, nil
+	default:
+		return nil, true, nil
+	}
+}
+
+// HandleSourceS3ToDestinationPVC writes the backup in the volume through the
+// loader pod and decompresses it, unless the manifest in the volume tells it
+// is already there, then removes the loader pod so other workloads can mount
+// the volume. The volume is reported in the status. It waits for the loader
+// pod to be gone before snapshotting the volume.
+func (r *BackupClaimReconciler) HandleSourceS3ToDestinationPVC(ctx context.Context, cc *claimContext, loader *corev1.Pod, s3file *source.S3File) (bool, error) {
+	if loader == nil {
+		return r.snapshotDelivered(ctx, cc, s3file)
+	}
+	path := filepath.Join(pod.LoaderMountPath, deliveredKey(cc.claim))
+	decompress, _ := pod.DecompressCommand(path)
+	decompressed := filepath.Join(pod.LoaderMountPath, volumeBackupPath(cc.claim))
+	podExec := r.NewExecutor(loader, "")
+
+	delivered, err := isDelivered(ctx, cc, podExec, decompressed, s3file)
+	if err != nil {
+		return true, err
+	}
+	if !delivered {
+		if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"mkdir", "-p", filepath.Dir(path)})); err != nil {
+			return true, fmt.Errorf("Could not create folder '%s': %v", filepath.Dir(path), err)
+		}
+		// Writes are appended, start over from an empty file
+		if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"rm", "-f", path, decompressed, manifestPath(decompressed)})); err != nil {
+			return true, fmt.Errorf("Could not remove previous backup '%s': %v", path, err)
+		}
+
+		cc.logger.Info("Uploading file to volume", "pvc", cc.claim.Spec.Destination.PVC.Name)
+		checksum, err := r.transfer(ctx, cc, s3file, podExec, loader, path)
+		if err != nil {
+			return true, err
+		}
+		if decompress != nil {
+			if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, decompress)); err != nil {
+				return true, fmt.Errorf("Could not decompress '%s': %v", path, err)
+			}
+		}
+		if err := writeManifest(ctx, podExec, decompressed, newManifest(cc.claim, s3file, checksum)); err != nil {
+			return true, err
+		}
+	}
+
+	// The writes may still be in the page cache of the node, flush them
+	// before the volume is unmounted
+	if _, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"sync"})); err != nil {
+		return true, fmt.Errorf("Could not flush the volume: %v", err)
+	}
+	// A ReadWriteOnce volume can only be mounted by one node
+	if err := r.Delete(ctx, loader); client.IgnoreNotFound(err) != nil {
+		return true, fmt.Errorf("Could not remove loader pod '%s': %v", loader.Name, err)
+	}
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonCleanedUp, "Removed loader pod %s/%s", loader.Namespace, loader.Name)
+
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: cc.claim.Spec.Destination.PVC.Name}, &pvc); err != nil {
+		return true, err
+	}
+	cc.claim.Status.Volume = &backupsv1beta1.BackupClaimVolumeStatus{
+		ClaimName:  pvc.Name,
+		VolumeName: pvc.Spec.VolumeName,
+		Path:       volumeBackupPath(cc.claim),
+		Version:    sourceVersion(s3file),
+	}
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonVolumeReady, "Backup is at %s in persistent volume claim %s", cc.claim.Status.Volume.Path, pvc.Name)
+	// The loader pod is still terminating, snapshot once it is gone
+	return cc.claim.Spec.Destination.PVC.Snapshot != nil, nil
+}
+
+// snapshotDelivered snapshots the volume holding the backup once the loader
+// pod is gone, unless it was already, or cloned from a snapshot
+func (r *BackupClaimReconciler) snapshotDelivered(ctx context.Context, cc *claimContext, s3file *source.S3File) (bool, error) {
+	volume := cc.claim.Status.Volume
+	if cc.claim.Spec.Destination.PVC.Snapshot == nil || volume.SnapshotName != "" || volume.ClonedFrom != "" {
+		cc.logger.Info("Backup claim is already ready")
+		return false, nil
+	}
+	var loader corev1.Pod
+	err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: pod.LoaderPodName(cc.claim.Name)}, &loader)
+	if err == nil {
+		cc.logger.Info("Waiting for the loader pod to be gone")
+		return true, nil
+	} else if !apierrors.IsNotFound(err) {
+		return true, err
+	}
+
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cc.claim.Namespace, Name: volume.ClaimName}, &pvc); err != nil {
+		return true, err
+	}
+	// The backup is in the volume, failing to snapshot it is not worth
+	// transferring it again
+	name, err := r.snapshotVolume(ctx, cc, &pvc, s3file)
+	if err != nil {
+		cc.logger.Error(err, "Could not snapshot volume")
+		r.recordEvent(cc.claim, corev1.EventTypeWarning, EventReasonSnapshotFailed, "Could not snapshot persistent volume claim %s: %s", pvc.Name, err.Error())
+	}
+	volume.SnapshotName = name
+	return false, nil
+}
+
+// volumeBackupPath is the path of the backup in the volume, once decompressed
+func volumeBackupPath(backupClaim *backupsv1beta1.BackupClaim) string {
+	_, decompressed := pod.DecompressCommand(deliveredKey(backupClaim))
+	return decompressed
+}
+
+// cloneSnapshot makes pvc a clone of snapshot, at least as big as the
+// snapshot
+func cloneSnapshot(pvc *corev1.PersistentVolumeClaim, snapshot *snapshotv1.VolumeSnapshot) {
+	group := snapshotv1.GroupName
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &group,
+		Kind:     "VolumeSnapshot",
+		Name:     snapshot.Name,
+	}
+	if restoreSize := snapshot.Status.RestoreSize; restoreSize != nil && restoreSize.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = restoreSize.DeepCopy()
+	}
+}
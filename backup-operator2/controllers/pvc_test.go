@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPVCDestination(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, backupsv1beta1.BackupClaimDestinationSpec{
+		PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "app-data"},
+	})
+	r := newTestReconciler(t, claim)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	pods := r.Pods
+	req := requestFor(claim)
+
+	// Creates the volume and the loader pod, then waits for the pod
+	r.reconcile(req)
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-data"}, &pvc); err != nil {
+		t.Fatalf("the volume should be created: %v", err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "1Gi" {
+		t.Errorf("small backups should get the minimum size, got %s", size.String())
+	}
+	var loader corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); err != nil {
+		t.Fatalf("the loader pod should be created: %v", err)
+	}
+	if claimName := loader.Spec.Volumes[0].PersistentVolumeClaim.ClaimName; claimName != "app-data" {
+		t.Errorf("the loader should mount the volume, got '%s'", claimName)
+	}
+
+	loader.Status.Phase = corev1.PodRunning
+	if err := r.Status().Update(ctx, &loader); err != nil {
+		t.Fatal(err)
+	}
+	pvc.Spec.VolumeName = "pv-1"
+	if err := r.Update(ctx, &pvc); err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(req)
+
+	data, ok := pods.ReadFile("team", "app-loader", "loader", "/data/"+strings.TrimSuffix(key, ".xz"))
+	if !ok || string(data) != "backup" {
+		t.Errorf("the loader should receive the backup, got '%s'", data)
+	}
+	decompressed := false
+	for _, command := range pods.Commands() {
+		decompressed = decompressed || command.String() == "xz -d -f /data/"+key
+	}
+	if !decompressed {
+		t.Errorf("the backup should be decompressed, ran %v", pods.Commands())
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); !apierrors.IsNotFound(err) {
+		t.Errorf("the loader pod should be removed, got %v", err)
+	}
+	claim = r.claim(req)
+	volume := claim.Status.Volume
+	if claim.Status.Status != backupsv1beta1.StatusReady || volume == nil {
+		t.Fatalf("the claim should be ready with its volume, got '%s' %v", claim.Status.Status, volume)
+	}
+	if *volume != (backupsv1beta1.BackupClaimVolumeStatus{ClaimName: "app-data", VolumeName: "pv-1", Path: strings.TrimSuffix(key, ".xz"), Version: "v1"}) {
+		t.Errorf("unexpected volume %+v", *volume)
+	}
+
+	if _, ok := pods.ReadFile("team", "app-loader", "loader", manifestPath("/data/"+volume.Path)); !ok {
+		t.Errorf("the manifest should be written in the volume")
+	}
+
+	// Delivered claims do not bring the loader back
+	r.reconcile(req)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); !apierrors.IsNotFound(err) {
+		t.Errorf("the loader pod should not be created again, got %v", err)
+	}
+
+	// Without the version in the status, the loader checks the manifest in
+	// the volume
+	claim = r.claim(req)
+	claim.Status.Volume = nil
+	if err := r.Status().Update(ctx, claim); err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(req)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); err != nil {
+		t.Fatalf("the loader pod should be created to read the manifest: %v", err)
+	}
+	loader.Status.Phase = corev1.PodRunning
+	if err := r.Status().Update(ctx, &loader); err != nil {
+		t.Fatal(err)
+	}
+	transfers := len(pods.Commands())
+	r.reconcile(req)
+	for _, command := range pods.Commands()[transfers:] {
+		if command.Command[0] == "xz" || command.Command[0] == "rm" {
+			t.Errorf("the backup in the volume should be kept, ran %v", command)
+		}
+	}
+	if claim := r.claim(req); claim.Status.Volume == nil || claim.Status.Volume.Version == "" {
+		t.Errorf("the version in the volume should be recorded, got %+v", claim.Status.Volume)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); !apierrors.IsNotFound(err) {
+		t.Errorf("the loader pod should be removed, got %v", err)
+	}
+
+	// Newer versions of the source are written in the volume
+	r.S3.AddObject(testBucket, key, []byte("newer"))
+	r.reconcile(req)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); err != nil {
+		t.Fatalf("the loader pod should be created for the new version: %v", err)
+	}
+	loader.Status.Phase = corev1.PodRunning
+	if err := r.Status().Update(ctx, &loader); err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(req)
+	if data, _ := pods.ReadFile("team", "app-loader", "loader", "/data/"+volume.Path); string(data) != "newer" {
+		t.Errorf("the new version should replace the backup, got '%s'", data)
+	}
+}
+
+func TestPVCDestinationSnapshot(t *testing.T) {
+	const key = "2021/12/01/app__1.sql"
+	ctx := context.Background()
+	newClaim := func(name string) *backupsv1beta1.BackupClaim {
+		return newTestClaim("team", name, key, backupsv1beta1.BackupClaimDestinationSpec{
+			PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{
+				Name:     name + "-data",
+				Snapshot: &backupsv1beta1.BackupClaimSnapshotSpec{},
+			},
+		})
+	}
+	// The finalizer keeps the loader pod terminating once deleted
+	loader := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "first-loader", Finalizers: []string{"kubernetes"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "loader"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	first, second := newClaim("first"), newClaim("second")
+	r := newTestReconciler(t, first, second, loader)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	pods := r.Pods
+
+	// The first claim transfers the backup and flushes it to the volume,
+	// then waits for the loader pod to release it
+	if result := r.reconcile(requestFor(first)); result.RequeueAfter == 0 {
+		t.Errorf("the claim should wait for the loader pod to be gone")
+	}
+	if commands := pods.Commands(); len(commands) == 0 || commands[len(commands)-1].String() != "sync" {
+		t.Errorf("the volume should be flushed before the loader pod is removed, ran %v", commands)
+	}
+	claim := r.claim(requestFor(first))
+	if claim.Status.Status != backupsv1beta1.StatusReconciling || claim.Status.Volume == nil || claim.Status.Volume.SnapshotName != "" {
+		t.Fatalf("the volume should not be snapshotted while mounted, got '%s' %+v", claim.Status.Status, claim.Status.Volume)
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(loader), loader); err != nil {
+		t.Fatal(err)
+	}
+	loader.Finalizers = nil
+	if err := r.Update(ctx, loader); err != nil {
+		t.Fatal(err)
+	}
+
+	// And snapshots the volume once it is gone
+	r.reconcile(requestFor(first))
+	claim = r.claim(requestFor(first))
+	if claim.Status.Status != backupsv1beta1.StatusReady || claim.Status.Volume == nil || claim.Status.Volume.SnapshotName == "" {
+		t.Fatalf("the snapshot should be recorded, got %+v", claim.Status.Volume)
+	}
+	var snapshot snapshotv1.VolumeSnapshot
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: claim.Status.Volume.SnapshotName}, &snapshot); err != nil {
+		t.Fatalf("the snapshot should be created: %v", err)
+	}
+	if source := snapshot.Spec.Source.PersistentVolumeClaimName; source == nil || *source != "first-data" {
+		t.Errorf("the snapshot should be taken of the volume, got %v", source)
+	}
+
+	// The second claim clones the volume once the snapshot is ready
+	ready := true
+	restoreSize := resource.MustParse("2Gi")
+	snapshot.Status = &snapshotv1.VolumeSnapshotStatus{ReadyToUse: &ready, RestoreSize: &restoreSize}
+	if err := r.Status().Update(ctx, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	transfers := len(pods.Commands())
+	r.reconcile(requestFor(second))
+	if len(pods.Commands()) != transfers {
+		t.Errorf("the clone should need no command, ran %v", pods.Commands()[transfers:])
+	}
+	var pvc corev1.PersistentVolumeClaim
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "second-data"}, &pvc); err != nil {
+		t.Fatal(err)
+	}
+	if pvc.Spec.DataSource == nil || pvc.Spec.DataSource.Kind != "VolumeSnapshot" || pvc.Spec.DataSource.Name != snapshot.Name {
+		t.Errorf("the volume should be cloned from the snapshot, got %+v", pvc.Spec.DataSource)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "2Gi" {
+		t.Errorf("the clone should be as big as the snapshot, got %s", size.String())
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "second-loader"}, &corev1.Pod{}); !apierrors.IsNotFound(err) {
+		t.Errorf("the clone needs no loader pod, got %v", err)
+	}
+	claim = r.claim(requestFor(second))
+	if claim.Status.Status != backupsv1beta1.StatusReady || claim.Status.Volume == nil || claim.Status.Volume.ClonedFrom != snapshot.Name {
+		t.Errorf("the claim should be ready with its clone, got '%s' %+v", claim.Status.Status, claim.Status.Volume)
+	}
+}
+
+func TestPVCDestinationForeignVolume(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, backupsv1beta1.BackupClaimDestinationSpec{
+		PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "mysql-data"},
+	})
+	foreign := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "mysql-data"}}
+	r := newTestReconciler(t, claim, foreign)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	req := requestFor(claim)
+
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("volumes not created by the claim should be refused")
+	}
+	if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusFailedToResolveDestination || !strings.Contains(claim.Status.Error, "was not created by the backup claim") {
+		t.Errorf("the claim should explain the refusal, got '%s' %s", claim.Status.Status, claim.Status.Error)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &corev1.Pod{}); !apierrors.IsNotFound(err) {
+		t.Errorf("no loader pod should mount the volume, got %v", err)
+	}
+}
+
+func TestPVCDestinationFailedLoader(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, backupsv1beta1.BackupClaimDestinationSpec{
+		PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "app-data"},
+	})
+	r := newTestReconciler(t, claim)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	req := requestFor(claim)
+
+	r.reconcile(req)
+	var loader corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); err != nil {
+		t.Fatalf("the loader pod should be created: %v", err)
+	}
+	loader.Status.Phase = corev1.PodFailed
+	if err := r.Status().Update(ctx, &loader); err != nil {
+		t.Fatal(err)
+	}
+
+	// Deletes the failed loader pod, then creates a new one
+	r.reconcile(req)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); !apierrors.IsNotFound(err) {
+		t.Fatalf("the failed loader pod should be deleted, got %v", err)
+	}
+	if claim := r.claim(req); claim.Status.Error != "" {
+		t.Errorf("a failed loader pod should not fail the claim, got '%s'", claim.Status.Error)
+	}
+	r.reconcile(req)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "team", Name: "app-loader"}, &loader); err != nil {
+		t.Fatalf("a new loader pod should be created: %v", err)
+	}
+	if loader.Status.Phase == corev1.PodFailed {
+		t.Errorf("the new loader pod should not be failed")
+	}
+}
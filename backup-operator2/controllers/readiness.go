@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nvanheuverzwijn/backup-operator/pkg/engine"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	// How many times the database is probed before coming back later
+	probeAttempts = 3
+	probeInterval = 2 * time.Second
+)
+
+// containerReady tells if the kubelet reports the container name of p as
+// running and ready
+func containerReady(p *corev1.Pod, name string) bool {
+	for _, status := range p.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.Ready && status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// databaseReady probes the database engineName in the container of p. Ready
+// containers may still be starting their database, when they have no
+// readiness probe.
+func (r *BackupClaimReconciler) databaseReady(ctx context.Context, cc *claimContext, p *corev1.Pod, container, engineName string) (bool, error) {
+	command, err := engine.ReadinessCommand(engineName)
+	if err != nil {
+		return false, err
+	}
+	podExec := r.NewExecutor(p, container)
+	for attempt := 1; ; attempt++ {
+		_, err = pod.MustSucceed(podExec.ExecCmd(ctx, command))
+		if err == nil {
+			return true, nil
+		}
+		if attempt >= probeAttempts {
+			break
+		}
+		select {
+		case <-time.After(probeInterval):
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+	cc.logger.Info("Database is not ready", "pod", p.Name, "container", container, "reason", fmt.Sprintf("%s is not ready after %d attempts: %v", engineName, probeAttempts, err))
+	return false, nil
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	podfake "github.com/nvanheuverzwijn/backup-operator/pkg/pod/fake"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// setReady fakes the kubelet reporting every container of p running and ready
+func setReady(p *corev1.Pod) {
+	p.Status.Phase = corev1.PodRunning
+	p.Status.ContainerStatuses = nil
+	for _, container := range p.Spec.Containers {
+		p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  container.Name,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			Ready: true,
+		})
+	}
+}
+
+func TestDatabaseReadiness(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	defer func(interval time.Duration) { probeInterval = interval }(probeInterval)
+	probeInterval = time.Millisecond
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, newPodDestination("app"))
+	childPod := newTestPod("team", "app-backupclaim", claim)
+	childPod.Status = corev1.PodStatus{Phase: corev1.PodRunning}
+	r := newTestReconciler(t, claim, childPod)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	req := requestFor(claim)
+
+	// Running is not ready
+	r.reconcile(req)
+	if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusReconciling || len(r.Pods.Commands()) != 0 {
+		t.Errorf("the claim should wait for the container to be ready, got '%s' after %v", claim.Status.Status, r.Pods.Commands())
+	}
+
+	// Ready is not accepting connections
+	setReady(childPod)
+	if err := r.Status().Update(ctx, childPod); err != nil {
+		t.Fatal(err)
+	}
+	r.Pods.Stub("mysqladmin ping", podfake.Result{ExitCode: 1, Stderr: "Can't connect to MySQL server on '127.0.0.1:3306'"})
+	r.reconcile(req)
+	if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusReconciling || len(r.Pods.Commands()) != probeAttempts {
+		t.Errorf("the claim should probe the database %d times then wait, got '%s' after %v", probeAttempts, claim.Status.Status, r.Pods.Commands())
+	}
+
+	r.Pods.Reset()
+	r.reconcile(req)
+	if claim := r.claim(req); claim.Status.Status != backupsv1beta1.StatusReady {
+		t.Errorf("the claim should be delivered once the database is up, got '%s' %s", claim.Status.Status, claim.Status.Error)
+	}
+	if commands := r.Pods.Commands(); len(commands) == 0 || commands[0].String() != "mysqladmin ping -h 127.0.0.1 --protocol=TCP --silent" {
+		t.Errorf("the database should be probed before delivering, ran %v", commands)
+	}
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Indexes the claims by the pods they delivered their backup to
+const deliveredPodKey = ".status.deliveredTo"
+
+// lostError is returned when the pods holding the backup were recreated or
+// failed, and the recovery policy of the claim is to degrade
+type lostError struct {
+	reason string
+}
+
+func (e *lostError) Error() string {
+	return fmt.Sprintf("backup lost: %s", e.reason)
+}
+
+// deliveredPods returns the pods as recorded in the status once they hold the
+// backup
+func deliveredPods(pods []corev1.Pod) []backupsv1beta1.BackupClaimDeliveredPod {
+	delivered := make([]backupsv1beta1.BackupClaimDeliveredPod, 0, len(pods))
+	for _, p := range pods {
+		restartCounts := make(map[string]int32, len(p.Status.ContainerStatuses))
+		for _, status := range p.Status.ContainerStatuses {
+			restartCounts[status.Name] = status.RestartCount
+		}
+		delivered = append(delivered, backupsv1beta1.BackupClaimDeliveredPod{
+			Namespace:     p.Namespace,
+			Name:          p.Name,
+			UID:           p.UID,
+			RestartCounts: restartCounts,
+		})
+	}
+	return delivered
+}
+
+// lostPods tells why pods, the destination of the claim, do not hold the
+// backup it delivered, or returns "" if they hold it or it was not delivered
+func lostPods(backupClaim *backupsv1beta1.BackupClaim, pods []corev1.Pod) string {
+	delivered := backupClaim.Status.DeliveredTo
+	if len(delivered) == 0 {
+		return ""
+	}
+	for _, p := range pods {
+		var found *backupsv1beta1.BackupClaimDeliveredPod
+		for i := range delivered {
+			if delivered[i].UID == p.UID {
+				found = &delivered[i]
+				break
+			}
+		}
+		if found == nil {
+			for _, d := range delivered {
+				if d.Namespace == p.Namespace && d.Name == p.Name {
+					return fmt.Sprintf("pod %s/%s was recreated", p.Namespace, p.Name)
+				}
+			}
+			return fmt.Sprintf("pod %s/%s replaced the pods it was delivered to", p.Namespace, p.Name)
+		}
+		if p.Status.Phase == corev1.PodFailed {
+			return fmt.Sprintf("pod %s/%s failed", p.Namespace, p.Name)
+		}
+		// Deliveries recorded without restart counts can not tell
+		for _, status := range p.Status.ContainerStatuses {
+			if restartCount, ok := found.RestartCounts[status.Name]; ok && status.RestartCount > restartCount {
+				return fmt.Sprintf("container %s of pod %s/%s restarted", status.Name, p.Namespace, p.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// recoverBackup follows the recovery policy of the claim once the backup it
+// delivered is lost. Restoring forgets the delivery, for the backup to be
+// delivered again; degrading returns a lostError.
+func (r *BackupClaimReconciler) recoverBackup(cc *claimContext, reason string) error {
+	if cc.claim.Spec.RecoveryPolicy == backupsv1beta1.RecoveryPolicyDegrade {
+		return &lostError{reason: reason}
+	}
+	cc.logger.Info("Backup lost, restoring it again", "reason", reason)
+	r.recordEvent(cc.claim, corev1.EventTypeWarning, EventReasonBackupLost, "Restoring the backup again: %s", reason)
+	cc.claim.Status.DeliveredTo = nil
+	now := metav1.Now()
+	cc.claim.Status.LostAt = &now
+	cc.claim.Status.Status = backupsv1beta1.StatusReconciling
+	return nil
+}
+
+// degraded marks the claim as degraded. Like timed out claims, degraded
+// claims are not requeued, changing the recovery policy to Restore recovers
+// them.
+func (r *BackupClaimReconciler) degraded(ctx context.Context, cc *claimContext, err error) {
+	if cc.claim.Status.Status != backupsv1beta1.StatusDegraded {
+		cc.logger.Info("Backup claim degraded", "reason", err.Error())
+		r.recordFailure(cc.claim, EventReasonDegraded, "Degraded: %s", err.Error())
+	}
+	cc.claim.Status.Status = backupsv1beta1.StatusDegraded
+	cc.claim.Status.Error = err.Error()
+	_ = r.Status().Update(ctx, cc.claim)
+	r.eventThrottle().Forget(client.ObjectKeyFromObject(cc.claim))
+}
+
+// claimsDeliveredTo returns the claims which delivered their backup to the
+// pod obj, to reconcile them when an existing pod they do not own changes
+func (r *BackupClaimReconciler) claimsDeliveredTo(ctx context.Context, obj client.Object) []reconcile.Request {
+	var claims backupsv1beta1.BackupClaimList
+	key := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+	if err := r.List(ctx, &claims, client.MatchingFields{deliveredPodKey: key}); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, claim := range claims.Items {
+		for _, d := range claim.Status.DeliveredTo {
+			if d.Namespace == obj.GetNamespace() && d.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: claim.Namespace, Name: claim.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecovery(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	for _, tc := range []struct {
+		policy string
+		lose   func(ctx context.Context, c client.Client, p *corev1.Pod) error
+		status string
+	}{
+		{
+			policy: backupsv1beta1.RecoveryPolicyRestore,
+			lose:   func(ctx context.Context, c client.Client, p *corev1.Pod) error { return c.Delete(ctx, p) },
+			status: backupsv1beta1.StatusReconciling,
+		},
+		{
+			policy: backupsv1beta1.RecoveryPolicyRestore,
+			lose: func(ctx context.Context, c client.Client, p *corev1.Pod) error {
+				p.Status.Phase = corev1.PodFailed
+				return c.Status().Update(ctx, p)
+			},
+			status: backupsv1beta1.StatusReconciling,
+		},
+		{
+			policy: backupsv1beta1.RecoveryPolicyDegrade,
+			lose:   func(ctx context.Context, c client.Client, p *corev1.Pod) error { return c.Delete(ctx, p) },
+			status: backupsv1beta1.StatusDegraded,
+		},
+	} {
+		ctx := context.Background()
+		claim := newTestClaim("team", "app", key, newPodDestination("app"))
+		claim.Spec.RecoveryPolicy = tc.policy
+		childPod := newTestPod("team", "app-backupclaim", claim)
+		childPod.UID = "first"
+		r := newTestReconciler(t, claim, childPod)
+		r.S3.AddObject(testBucket, key, []byte("backup"))
+		pods := r.Pods
+		req := requestFor(claim)
+		r.reconcile(req)
+		claim = r.claim(req)
+		if claim.Status.Status != backupsv1beta1.StatusReady || len(claim.Status.DeliveredTo) != 1 || claim.Status.DeliveredTo[0].UID != "first" {
+			t.Fatalf("the claim should record the pod it delivered to, got '%s' %+v", claim.Status.Status, claim.Status.DeliveredTo)
+		}
+
+		if err := tc.lose(ctx, r.Client, childPod); err != nil {
+			t.Fatal(err)
+		}
+		r.reconcile(req)
+		claim = r.claim(req)
+		if claim.Status.Status != tc.status {
+			t.Errorf("%s: expected status '%s', got '%s' %s", tc.policy, tc.status, claim.Status.Status, claim.Status.Error)
+		}
+		var childPods corev1.PodList
+		if err := r.List(ctx, &childPods); err != nil {
+			t.Fatal(err)
+		}
+		if tc.policy == backupsv1beta1.RecoveryPolicyDegrade {
+			if len(childPods.Items) != 0 || !strings.Contains(claim.Status.Error, "pod team/app-backupclaim was deleted") {
+				t.Errorf("degraded claims should explain the loss and leave the pods alone, got %d pods, '%s'", len(childPods.Items), claim.Status.Error)
+			}
+			continue
+		}
+		if len(childPods.Items) != 1 || childPods.Items[0].UID == "first" || claim.Status.DeliveredTo != nil || claim.Status.LostAt == nil {
+			t.Fatalf("the pod should be replaced and the delivery forgotten, got %d pods, %+v", len(childPods.Items), claim.Status)
+		}
+
+		// The replacement pod receives the backup again
+		pods.Reset()
+		replacement := childPods.Items[0]
+		setReady(&replacement)
+		if err := r.Status().Update(ctx, &replacement); err != nil {
+			t.Fatal(err)
+		}
+		r.reconcile(req)
+		claim = r.claim(req)
+		if claim.Status.Status != backupsv1beta1.StatusReady || len(claim.Status.DeliveredTo) != 1 || claim.Status.DeliveredTo[0].Name != replacement.Name {
+			t.Errorf("the backup should be restored in the new pod, got '%s' %+v", claim.Status.Status, claim.Status.DeliveredTo)
+		}
+		imported := false
+		for _, command := range pods.Commands() {
+			imported = imported || (command.PodName == replacement.Name && command.String() == "mysql --database=20211201app__1" && string(command.Stdin) == "backup")
+		}
+		if !imported {
+			t.Errorf("the backup should be imported in the new pod, ran %v", pods.Commands())
+		}
+	}
+}
+
+func TestRecoveryRestartedContainer(t *testing.T) {
+	const key = "2021/12/01/app__1.sql.xz"
+	const path = "/tmp/" + testBucket + "/" + key
+	ctx := context.Background()
+	claim := newTestClaim("team", "app", key, existingPodDestination("db", "mysql-0"))
+	claim.Spec.RecoveryPolicy = backupsv1beta1.RecoveryPolicyRestore
+	existingPod := newTestPod("db", "mysql-0", nil)
+	r := newTestReconciler(t, claim, existingPod)
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	req := requestFor(claim)
+	r.reconcile(req)
+	if delivered := r.claim(req).Status.DeliveredTo; len(delivered) != 1 || delivered[0].RestartCounts["mysql"] != 0 {
+		t.Fatalf("the claim should record the restart count of the containers, got %+v", delivered)
+	}
+
+	// The restarted container lost the backup
+	existingPod.Status.ContainerStatuses[0].RestartCount = 1
+	if err := r.Status().Update(ctx, existingPod); err != nil {
+		t.Fatal(err)
+	}
+	r.Pods.Reset()
+	r.reconcile(req)
+	claim = r.claim(req)
+	if claim.Status.Status != backupsv1beta1.StatusReady || claim.Status.LostAt == nil || claim.Status.DeliveredTo[0].RestartCounts["mysql"] != 1 {
+		t.Errorf("the backup should be restored in the restarted container, got '%s' %+v", claim.Status.Status, claim.Status)
+	}
+	if data, _ := r.Pods.ReadFile("db", "mysql-0", "mysql", path); string(data) != "backup" {
+		t.Errorf("the backup should be sent again, got '%s' after %v", data, r.Pods.Commands())
+	}
+}
+
+func TestLostPods(t *testing.T) {
+	claim := &backupsv1beta1.BackupClaim{}
+	pod := func(name string, uid types.UID) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "db", Name: name, UID: uid}}
+	}
+	if reason := lostPods(claim, []corev1.Pod{pod("mysql-0", "a")}); reason != "" {
+		t.Errorf("claims which did not deliver lose nothing, got '%s'", reason)
+	}
+
+	restarted := func(p corev1.Pod, restartCount int32) corev1.Pod {
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "mysql", RestartCount: restartCount}}
+		return p
+	}
+	failed := func(p corev1.Pod) corev1.Pod {
+		p.Status.Phase = corev1.PodFailed
+		return p
+	}
+
+	claim.Status.DeliveredTo = deliveredPods([]corev1.Pod{restarted(pod("mysql-0", "a"), 1), pod("mysql-1", "b")})
+	for _, tc := range []struct {
+		pods   []corev1.Pod
+		reason string
+	}{
+		{pods: []corev1.Pod{restarted(pod("mysql-0", "a"), 1), pod("mysql-1", "b")}},
+		{pods: []corev1.Pod{pod("mysql-1", "b")}},
+		{pods: []corev1.Pod{pod("mysql-0", "a"), pod("mysql-1", "c")}, reason: "pod db/mysql-1 was recreated"},
+		{pods: []corev1.Pod{pod("mysql-2", "d")}, reason: "pod db/mysql-2 replaced the pods it was delivered to"},
+		{pods: []corev1.Pod{failed(pod("mysql-1", "b"))}, reason: "pod db/mysql-1 failed"},
+		{pods: []corev1.Pod{restarted(pod("mysql-0", "a"), 2)}, reason: "container mysql of pod db/mysql-0 restarted"},
+		// Restart counts were not recorded for mysql-1
+		{pods: []corev1.Pod{restarted(pod("mysql-1", "b"), 3)}},
+	} {
+		if reason := lostPods(claim, tc.pods); reason != tc.reason {
+			t.Errorf("expected '%s', got '%s'", tc.reason, reason)
+		}
+	}
+}
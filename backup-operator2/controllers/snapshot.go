@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/source"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Identifies the version of the backup held by a snapshot
+	snapshotSourceLabel = "backups.nvanheuverzwijn.io/source"
+	// The version of the backup held by a snapshot, for humans
+	snapshotSourceAnnotation = "backups.nvanheuverzwijn.io/source-url"
+)
+
+// sourceID identifies the version of the backup read by s3file, as a label
+// value
+func sourceID(s3file *source.S3File) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s?versionId=%s&etag=%s", s3file.URL(), s3file.VersionID(), s3file.ETag())))
+	return hex.EncodeToString(sum[:16])
+}
+
+// findSnapshot returns a snapshot of the namespace holding the version of the
+// backup read by s3file and ready to be cloned, or nil if there is none
+func (r *BackupClaimReconciler) findSnapshot(ctx context.Context, namespace string, s3file *source.S3File) (*snapshotv1.VolumeSnapshot, error) {
+	var snapshots snapshotv1.VolumeSnapshotList
+	if err := r.List(ctx, &snapshots, client.InNamespace(namespace), client.MatchingLabels{snapshotSourceLabel: sourceID(s3file)}); err != nil {
+		return nil, fmt.Errorf("Could not list volume snapshots: %s", err.Error())
+	}
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if snapshot.DeletionTimestamp != nil || snapshot.Status == nil {
+			continue
+		}
+		if snapshot.Status.ReadyToUse != nil && *snapshot.Status.ReadyToUse {
+			return snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+// snapshotVolume takes a snapshot of pvc, which holds the backup read by
+// s3file, and returns its name. The snapshot is not owned by the claim, it
+// outlives it to be cloned by later claims.
+func (r *BackupClaimReconciler) snapshotVolume(ctx context.Context, cc *claimContext, pvc *corev1.PersistentVolumeClaim, s3file *source.S3File) (string, error) {
+	id := sourceID(s3file)
+	snapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", pvc.Name, id[:8]),
+			Namespace:   pvc.Namespace,
+			Labels:      map[string]string{snapshotSourceLabel: id},
+			Annotations: map[string]string{snapshotSourceAnnotation: fmt.Sprintf("%s?versionId=%s", s3file.URL(), s3file.VersionID())},
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source:                  snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvc.Name},
+			VolumeSnapshotClassName: cc.claim.Spec.Destination.PVC.Snapshot.ClassName,
+		},
+	}
+	if err := r.Create(ctx, snapshot); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", err
+	}
+	r.recordEvent(cc.claim, corev1.EventTypeNormal, EventReasonSnapshotCreated, "Created volume snapshot %s/%s of %s", snapshot.Namespace, snapshot.Name, pvc.Name)
+	return snapshot.Name, nil
+}
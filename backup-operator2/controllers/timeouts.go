@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Steps of a claim with a timeout
+const (
+	stepPodReady = "podReady"
+	stepTransfer = "transfer"
+	stepImport   = "import"
+)
+
+// timeoutError is returned by the steps running longer than their timeout
+type timeoutError struct {
+	step    string
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s", e.step, e.timeout)
+}
+
+// withTimeout returns ctx bounded by the timeout of a step, if it has one
+func withTimeout(ctx context.Context, timeout *metav1.Duration) (context.Context, context.CancelFunc) {
+	if timeout == nil || timeout.Duration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout.Duration)
+}
+
+// stepTimedOut returns a timeoutError if ctx of the step ran out of time
+func stepTimedOut(ctx context.Context, step string, timeout *metav1.Duration) error {
+	if timeout != nil && ctx.Err() == context.DeadlineExceeded {
+		return &timeoutError{step: step, timeout: timeout.Duration}
+	}
+	return nil
+}
+
+// podReadyTimeout returns a timeoutError once the claim waited longer than
+// its podReady timeout for the destination pod, and otherwise how long it may
+// still wait. The wait is unbounded without timeout, and starts over when the
+// backup is lost.
+func podReadyTimeout(claim *backupsv1beta1.BackupClaim, now time.Time) (time.Duration, error) {
+	timeout := claim.Spec.Timeouts.PodReady
+	if timeout == nil || timeout.Duration <= 0 {
+		return 0, nil
+	}
+	start := claim.CreationTimestamp
+	if claim.Status.LostAt != nil && claim.Status.LostAt.After(start.Time) {
+		start = *claim.Status.LostAt
+	}
+	remaining := start.Add(timeout.Duration).Sub(now)
+	if remaining <= 0 {
+		return 0, &timeoutError{step: stepPodReady, timeout: timeout.Duration}
+	}
+	return remaining, nil
+}
+
+// timedOut marks the claim as timed out. Timed out claims are not requeued,
+// the next change of the claim or of its pod retries them.
+func (r *BackupClaimReconciler) timedOut(ctx context.Context, cc *claimContext, err error) {
+	cc.logger.Info("Backup claim timed out", "reason", err.Error())
+	r.recordFailure(cc.claim, EventReasonTimedOut, "Timed out: %s", err.Error())
+	cc.claim.Status.Status = backupsv1beta1.StatusTimedOut
+	cc.claim.Status.Error = err.Error()
+	_ = r.Status().Update(ctx, cc.claim)
+	r.eventThrottle().Forget(client.ObjectKeyFromObject(cc.claim))
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	podfake "github.com/nvanheuverzwijn/backup-operator/pkg/pod/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodReadyTimeout(t *testing.T) {
+	created := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	claim := &backupsv1beta1.BackupClaim{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+
+	if remaining, err := podReadyTimeout(claim, created.Add(time.Hour)); remaining != 0 || err != nil {
+		t.Errorf("claims without timeout should wait forever, got %s, %v", remaining, err)
+	}
+
+	claim.Spec.Timeouts.PodReady = &metav1.Duration{Duration: 10 * time.Minute}
+	if remaining, err := podReadyTimeout(claim, created.Add(4*time.Minute)); remaining != 6*time.Minute || err != nil {
+		t.Errorf("expected 6m left, got %s, %v", remaining, err)
+	}
+	_, err := podReadyTimeout(claim, created.Add(10*time.Minute))
+	var timeout *timeoutError
+	if !errors.As(err, &timeout) || err.Error() != "podReady timed out after 10m0s" {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	lost := metav1.NewTime(created.Add(time.Hour))
+	claim.Status.LostAt = &lost
+	if remaining, err := podReadyTimeout(claim, created.Add(time.Hour+time.Minute)); remaining != 9*time.Minute || err != nil {
+		t.Errorf("the wait should start over once the backup is lost, got %s, %v", remaining, err)
+	}
+}
+
+func TestImportTimeout(t *testing.T) {
+	const key = "2021/12/01/slow__1.sql.xz"
+	claim := newTestClaim("team", "slow", key, newPodDestination("slow"))
+	claim.Spec.Timeouts.Import = &metav1.Duration{Duration: 100 * time.Millisecond}
+	r := newTestReconciler(t, claim, newTestPod("team", "slow-backupclaim", claim))
+	r.S3.AddObject(testBucket, key, []byte("backup"))
+	r.Pods.Stub("mysql --database=20211201slow__1", podfake.Result{Delay: time.Minute})
+
+	start := time.Now()
+	req := requestFor(claim)
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("timed out claims should not be retried, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("the import should have been stopped, reconcile took %s", elapsed)
+	}
+
+	if current := r.claim(req); current.Status.Status != backupsv1beta1.StatusTimedOut || current.Status.Error != "import timed out after 100ms" {
+		t.Errorf("unexpected status '%s': %s", current.Status.Status, current.Status.Error)
+	}
+}
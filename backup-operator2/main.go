@@ -17,22 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
 	"github.com/nvanheuverzwijn/backup-operator/controllers"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/policy"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/tracing"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -45,6 +53,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(backupsv1beta1.AddToScheme(scheme))
+	utilruntime.Must(snapshotv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -52,11 +61,29 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var awsWebIdentityRoleARN string
+	var awsWebIdentityTokenFile string
+	var enableWebhooks bool
+	var tracingExporter string
+	var tracingEndpoint string
+	var maxConcurrentReconciles int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&awsWebIdentityRoleARN, "aws-web-identity-role-arn", "",
+		"Role assumed with the projected service account token to get the operator's base AWS credentials.")
+	flag.StringVar(&awsWebIdentityTokenFile, "aws-web-identity-token-file", "",
+		"Projected service account token used with --aws-web-identity-role-arn.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the BackupClaim validating webhook enforcing BackupSourcePolicies. Requires serving certificates.")
+	flag.StringVar(&tracingExporter, "tracing-exporter", tracing.ExporterNone,
+		"Where to send the traces of the reconciliations: none, stdout or otlp.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "http://localhost:4318",
+		"URL of the OTLP/HTTP collector receiving the traces with --tracing-exporter=otlp.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"Number of BackupClaims reconciled at the same time.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -65,10 +92,23 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	shutdownTracing, err := tracing.Setup(tracingExporter, tracingEndpoint)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			setupLog.Error(err, "unable to flush traces")
+		}
+	}()
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
-		MetricsBindAddress:     metricsAddr,
-		Port:                   9443,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "756609ca.nvanheuverzwijn.io",
@@ -79,12 +119,24 @@ func main() {
 	}
 
 	if err = (&controllers.BackupClaimReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		Recorder:                mgr.GetEventRecorderFor("backupclaim-controller"),
+		AwsWebIdentityRoleARN:   awsWebIdentityRoleARN,
+		AwsWebIdentityTokenFile: awsWebIdentityTokenFile,
+		MaxConcurrentReconciles: maxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BackupClaim")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		mgr.GetWebhookServer().Register(policy.WebhookPath, &webhook.Admission{
+			Handler: &policy.BackupClaimValidator{
+				Client:  mgr.GetClient(),
+				Decoder: admission.NewDecoder(mgr.GetScheme()),
+			},
+		})
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
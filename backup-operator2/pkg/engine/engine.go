@@ -0,0 +1,31 @@
+// Package engine knows the databases backups are restored in: how to tell
+// they accept connections, and how to name and quote their identifiers
+package engine
+
+import "fmt"
+
+// Engines of the databases
+const (
+	MySQL      = "mysql"
+	PostgreSQL = "postgres"
+	MongoDB    = "mongodb"
+)
+
+// Commands exiting with 0 once the database accepts connections, by engine.
+// They connect over TCP: the temporary server initializing the database of
+// the official images only listens on the socket, and restarts once done.
+var readinessCommands = map[string][]string{
+	MySQL:      {"mysqladmin", "ping", "-h", "127.0.0.1", "--protocol=TCP", "--silent"},
+	PostgreSQL: {"pg_isready", "-h", "127.0.0.1", "--quiet"},
+	MongoDB:    {"mongosh", "--quiet", "--eval", "db.adminCommand({ping: 1})"},
+}
+
+// ReadinessCommand returns the command telling if the database of engine
+// accepts connections
+func ReadinessCommand(engine string) ([]string, error) {
+	command, ok := readinessCommands[engine]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine '%s'", engine)
+	}
+	return append([]string{}, command...), nil
+}
@@ -0,0 +1,20 @@
+package engine
+
+import "testing"
+
+func TestReadinessCommand(t *testing.T) {
+	for _, name := range []string{MySQL, PostgreSQL, MongoDB} {
+		command, err := ReadinessCommand(name)
+		if err != nil || len(command) == 0 {
+			t.Errorf("%s should have a readiness command, got %v %v", name, command, err)
+		}
+		// Callers may append arguments
+		command[0] = "changed"
+		if again, _ := ReadinessCommand(name); again[0] == "changed" {
+			t.Errorf("%s readiness command should be copied", name)
+		}
+	}
+	if _, err := ReadinessCommand("oracle"); err == nil {
+		t.Error("unknown engines should fail")
+	}
+}
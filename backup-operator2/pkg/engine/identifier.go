@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Longest identifiers, by engine
+var maxIdentifierLengths = map[string]int{
+	MySQL:      64,
+	PostgreSQL: 63,
+	MongoDB:    63,
+}
+
+// Quotes of identifiers in the SQL of the engines
+var identifierQuotes = map[string]string{
+	MySQL:      "`",
+	PostgreSQL: `"`,
+}
+
+// SanitizeIdentifier keeps the ASCII letters, digits and underscores of s, up
+// to the longest identifier of engine. Names from backup keys are safe to use
+// once sanitized, even unquoted as arguments. It returns "" when nothing is
+// left.
+func SanitizeIdentifier(engine, s string) (string, error) {
+	max, ok := maxIdentifierLengths[engine]
+	if !ok {
+		return "", fmt.Errorf("unknown engine '%s'", engine)
+	}
+	var b strings.Builder
+	for _, c := range s {
+		if b.Len() == max {
+			break
+		}
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// QuoteIdentifier quotes name for the SQL of engine, doubling the quotes it
+// holds
+func QuoteIdentifier(engine, name string) (string, error) {
+	quote, ok := identifierQuotes[engine]
+	if !ok {
+		return "", fmt.Errorf("engine '%s' has no quoted identifiers", engine)
+	}
+	if name == "" || strings.ContainsRune(name, 0) {
+		return "", fmt.Errorf("invalid identifier '%s'", name)
+	}
+	return quote + strings.ReplaceAll(name, quote, quote+quote) + quote, nil
+}
+
+// QuoteLiteral quotes s as a string literal of the SQL of engine. MySQL
+// treats backslashes as escapes, PostgreSQL, with its standard conforming
+// strings, does not.
+func QuoteLiteral(engine, s string) (string, error) {
+	if strings.ContainsRune(s, 0) {
+		return "", fmt.Errorf("invalid literal '%s'", s)
+	}
+	switch engine {
+	case MySQL:
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	case PostgreSQL:
+	default:
+		return "", fmt.Errorf("engine '%s' has no string literals", engine)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+// Keys of backups crafted to break out of commands and statements
+var hostileKeys = []string{
+	"app`; DROP DATABASE mysql; -- ",
+	"app'; DROP TABLE users; --",
+	`app"; DROP DATABASE postgres; --`,
+	"app$(reboot)",
+	"app && rm -rf /",
+	"--execute=DROP DATABASE app",
+	"app\\`\x00",
+	"app\n; SHUTDOWN",
+}
+
+func TestSanitizeIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		engine, s, name string
+	}{
+		{engine: MySQL, s: "20211201app__1", name: "20211201app__1"},
+		{engine: MySQL, s: "app`; DROP DATABASE mysql; -- ", name: "appDROPDATABASEmysql"},
+		{engine: MySQL, s: "--execute=DROP DATABASE app", name: "executeDROPDATABASEapp"},
+		{engine: MySQL, s: "bäckup", name: "bckup"},
+		{engine: MySQL, s: "$(;)", name: ""},
+		{engine: MySQL, s: strings.Repeat("a", 100), name: strings.Repeat("a", 64)},
+		{engine: PostgreSQL, s: strings.Repeat("a", 100), name: strings.Repeat("a", 63)},
+	} {
+		name, err := SanitizeIdentifier(test.engine, test.s)
+		if err != nil || name != test.name {
+			t.Errorf("%s '%s': expected '%s', got '%s' %v", test.engine, test.s, test.name, name, err)
+		}
+	}
+	for _, key := range hostileKeys {
+		name, _ := SanitizeIdentifier(MySQL, key)
+		if strings.Trim(name, "_abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" || strings.HasPrefix(name, "-") {
+			t.Errorf("'%s' should be sanitized, got '%s'", key, name)
+		}
+	}
+	if _, err := SanitizeIdentifier("oracle", "app"); err == nil {
+		t.Error("unknown engines should fail")
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	for _, test := range []struct {
+		engine, name, quoted string
+	}{
+		{engine: MySQL, name: "app", quoted: "`app`"},
+		{engine: MySQL, name: "app`; DROP DATABASE mysql; -- ", quoted: "`app``; DROP DATABASE mysql; -- `"},
+		{engine: PostgreSQL, name: `app"; DROP DATABASE postgres; --`, quoted: `"app""; DROP DATABASE postgres; --"`},
+		{engine: PostgreSQL, name: "app`", quoted: "\"app`\""},
+	} {
+		quoted, err := QuoteIdentifier(test.engine, test.name)
+		if err != nil || quoted != test.quoted {
+			t.Errorf("%s '%s': expected %s, got %s %v", test.engine, test.name, test.quoted, quoted, err)
+		}
+	}
+	for _, name := range []string{"", "app\x00"} {
+		if _, err := QuoteIdentifier(MySQL, name); err == nil {
+			t.Errorf("'%s' should not be quoted", name)
+		}
+	}
+	if _, err := QuoteIdentifier(MongoDB, "app"); err == nil {
+		t.Error("engines without SQL should fail")
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	for _, test := range []struct {
+		engine, s, quoted string
+	}{
+		{engine: MySQL, s: "restore_1f", quoted: "'restore_1f'"},
+		{engine: MySQL, s: "app'; DROP TABLE users; --", quoted: "'app''; DROP TABLE users; --'"},
+		{engine: MySQL, s: `app\'; --`, quoted: `'app\\''; --'`},
+		{engine: PostgreSQL, s: `app\'; --`, quoted: `'app\''; --'`},
+	} {
+		quoted, err := QuoteLiteral(test.engine, test.s)
+		if err != nil || quoted != test.quoted {
+			t.Errorf("%s '%s': expected %s, got %s %v", test.engine, test.s, test.quoted, quoted, err)
+		}
+	}
+	if _, err := QuoteLiteral(MySQL, "app\x00"); err == nil {
+		t.Error("literals with NUL should fail")
+	}
+}
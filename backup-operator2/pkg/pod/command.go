@@ -0,0 +1,92 @@
+package pod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Command is a program and its arguments run in a container. The arguments
+// are passed as is, without a shell: they need no quoting and can not inject
+// commands, whatever they hold. Redirections are replaced by streams, like
+// StdinFile.
+//
+//	result, err := pod.MustSucceed(pod.NewCommand("mysql", "--database="+name).StdinFile(dump).Run(ctx, executor))
+type Command struct {
+	args      []string
+	stdin     io.Reader
+	stdinFile string
+}
+
+// NewCommand returns the command running program with args
+func NewCommand(program string, args ...string) *Command {
+	return &Command{args: append([]string{program}, args...)}
+}
+
+// Arg appends args to the command
+func (c *Command) Arg(args ...string) *Command {
+	c.args = append(c.args, args...)
+	return c
+}
+
+// Stdin streams r to the standard input of the program
+func (c *Command) Stdin(r io.Reader) *Command {
+	c.stdin = r
+	c.stdinFile = ""
+	return c
+}
+
+// StdinFile streams the file of the container at path to the standard input
+// of the program, like the shell redirection `program < path`. The file is
+// read through the operator.
+func (c *Command) StdinFile(path string) *Command {
+	c.stdinFile = path
+	c.stdin = nil
+	return c
+}
+
+// Args returns the program and its arguments
+func (c *Command) Args() []string {
+	return append([]string{}, c.args...)
+}
+
+// String returns the arguments joined by spaces, with the redirection of the
+// input file. It is meant for logs, not for a shell.
+func (c *Command) String() string {
+	s := strings.Join(c.args, " ")
+	if c.stdinFile != "" {
+		s += " < " + c.stdinFile
+	}
+	return s
+}
+
+// Run runs the command with executor and returns its result, like ExecCmd. A
+// command exiting with a non-zero code is reported in ExecResult.ExitCode,
+// wrap with MustSucceed to treat it as an error.
+func (c *Command) Run(ctx context.Context, executor Executor) (*ExecResult, error) {
+	if c.stdin == nil && c.stdinFile == "" {
+		return executor.ExecCmd(ctx, c.Args())
+	}
+	stdin := c.stdin
+	if c.stdinFile != "" {
+		f := executor.File(ctx, c.stdinFile)
+		defer f.Close()
+		// The program would otherwise read an empty input
+		if _, err := f.Stat(); err != nil {
+			return nil, fmt.Errorf("Could not read input of '%s': %v", c.args[0], err)
+		}
+		stdin = f
+	}
+	result := &ExecResult{Command: c.Args(), Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	start := time.Now()
+	code, err := executor.Exec(ctx, result.Command, stdin, result.Stdout, result.Stderr)
+	result.Duration = time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("Could not run exec operation: %v", err)
+	}
+	result.ExitCode = code
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package pod
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	ctx := context.Background()
+	podExec := newLocalPodExec(t)
+
+	// Arguments reach the program as is, nothing is run by a shell
+	for _, arg := range []string{
+		"app`; DROP DATABASE mysql; -- ",
+		"$(touch /tmp/pwned)",
+		"app && rm -rf /",
+		"app' \"quoted\"",
+		"-n",
+	} {
+		result, err := MustSucceed(NewCommand("printf", "%s").Arg(arg).Run(ctx, podExec))
+		if err != nil {
+			t.Fatalf("could not run printf: %v", err)
+		}
+		if out := result.Stdout.String(); out != arg {
+			t.Errorf("expected '%s', got '%s'", arg, out)
+		}
+	}
+
+	dump := filepath.Join(t.TempDir(), "dump `$(reboot)`.sql")
+	if err := os.WriteFile(dump, []byte("CREATE TABLE users (id INT);\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	command := NewCommand("cat").StdinFile(dump)
+	if command.String() != "cat < "+dump {
+		t.Errorf("unexpected description '%s'", command)
+	}
+	result, err := MustSucceed(command.Run(ctx, podExec))
+	if err != nil || result.Stdout.String() != "CREATE TABLE users (id INT);\n" {
+		t.Errorf("the file should be streamed to the program, got '%s' %v", result.Stdout, err)
+	}
+
+	result, err = MustSucceed(NewCommand("wc", "-c").Stdin(bytes.NewBufferString("12345")).Run(ctx, podExec))
+	if err != nil || strings.TrimSpace(result.Stdout.String()) != "5" {
+		t.Errorf("the reader should be streamed to the program, got '%s' %v", result.Stdout, err)
+	}
+
+	if _, err := NewCommand("cat").StdinFile(dump+".missing").Run(ctx, podExec); err == nil {
+		t.Error("missing input files should fail")
+	}
+}
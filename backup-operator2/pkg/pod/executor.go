@@ -0,0 +1,57 @@
+package pod
+
+import (
+	"context"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Executor runs commands and accesses files in a container
+type Executor interface {
+	// Exec runs command with the given streams, which may be nil, and
+	// returns its exit code. err is only set when the command could not run.
+	// The command is stopped when ctx is done.
+	Exec(ctx context.Context, command []string, stdin io.Reader, stdout, stderr io.Writer) (int, error)
+	// ExecCmd runs command and returns its result. err is only set when the
+	// command could not run, a command exiting with a non-zero code is
+	// reported in ExecResult.ExitCode. Wrap with MustSucceed to treat both
+	// as errors.
+	//
+	// The command is stopped when ctx is done. Commands are traced as
+	// children of the span of ctx.
+	ExecCmd(ctx context.Context, command []string) (*ExecResult, error)
+	// File returns a file of the container. Reads and writes fail once ctx
+	// is done.
+	File(ctx context.Context, path string) File
+}
+
+// File of a container. Writes are appended to the file, Read starts at the
+// offset set by Seek. Close stops a running Read.
+type File interface {
+	io.ReadWriteCloser
+	io.ReaderAt
+	io.Seeker
+	// Stat returns the size, mode and modification time of the file
+	Stat() (os.FileInfo, error)
+}
+
+// ExecutorFactory returns the Executor of a container of a pod. The pod is
+// usually read from the cache of the controller, it is not fetched again.
+type ExecutorFactory func(pod *corev1.Pod, containername string) Executor
+
+// NewExecutorFactory returns a factory of executors running commands through
+// the exec subresource of the pods
+func NewExecutorFactory(config *rest.Config, clientset *kubernetes.Clientset) ExecutorFactory {
+	return func(pod *corev1.Pod, containername string) Executor {
+		return NewPodExec(*config, clientset, pod, containername)
+	}
+}
+
+// File returns a PodFile for path
+func (p *PodExec) File(ctx context.Context, path string) File {
+	return NewPodFile(ctx, path, p)
+}
@@ -0,0 +1,321 @@
+// Package fake provides an in-memory pod filesystem and command recorder to
+// test the delivery of backups without running pods
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nvanheuverzwijn/backup-operator/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Command run by an Executor
+type Command struct {
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Command       []string
+	// What was read from the standard input
+	Stdin []byte
+}
+
+// String returns the command joined by spaces
+func (c Command) String() string {
+	return strings.Join(c.Command, " ")
+}
+
+// Result stubbed for the commands containing a given string. Err makes the
+// command fail to run, ExitCode makes it run and fail. Delay makes it hang,
+// until the context of the command is done.
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Err      error
+	Delay    time.Duration
+}
+
+// Pods records the commands run in every container and holds their files.
+// Commands not stubbed succeed without output.
+type Pods struct {
+	mu         sync.Mutex
+	commands   []Command
+	files      map[string][]byte
+	stubs      []stub
+	writeError error
+}
+
+// stub is the result of the commands containing match
+type stub struct {
+	match  string
+	result Result
+}
+
+func NewPods() *Pods {
+	return &Pods{
+		files: make(map[string][]byte),
+	}
+}
+
+// Factory returns an ExecutorFactory of executors backed by p
+func (p *Pods) Factory() pod.ExecutorFactory {
+	return func(target *corev1.Pod, containername string) pod.Executor {
+		if containername == "" && len(target.Spec.Containers) > 0 {
+			containername = target.Spec.Containers[0].Name
+		}
+		return &Executor{pods: p, namespace: target.Namespace, podName: target.Name, containerName: containername}
+	}
+}
+
+// Stub makes the commands containing match return result. Commands matching
+// several stubs return the result of the longest match, the first stubbed
+// among equally long ones. Stubbing a match again replaces its result.
+func (p *Pods) Stub(match string, result Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.stubs {
+		if p.stubs[i].match == match {
+			p.stubs[i].result = result
+			return
+		}
+	}
+	p.stubs = append(p.stubs, stub{match: match, result: result})
+}
+
+// FailWrites makes every write to a file return err
+func (p *Pods) FailWrites(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.writeError = err
+}
+
+// Commands returns the commands run so far
+func (p *Pods) Commands() []Command {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Command{}, p.commands...)
+}
+
+// ReadFile returns the content of a file of a container
+func (p *Pods) ReadFile(namespace, podname, containername, path string) ([]byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, ok := p.files[fileKey(namespace, podname, containername, path)]
+	return append([]byte{}, data...), ok
+}
+
+// Reset forgets the commands and files
+func (p *Pods) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.commands = nil
+	p.files = make(map[string][]byte)
+	p.stubs = nil
+	p.writeError = nil
+}
+
+func fileKey(namespace, podname, containername, path string) string {
+	return namespace + "/" + podname + "/" + containername + ":" + path
+}
+
+// Executor of a container of the fake Pods
+type Executor struct {
+	pods          *Pods
+	namespace     string
+	podName       string
+	containerName string
+}
+
+// Exec runs the stubbed command, writing its output to stdout and stderr.
+// stdin is read and recorded with the command.
+func (e *Executor) Exec(ctx context.Context, command []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	cmd := Command{Namespace: e.namespace, PodName: e.podName, ContainerName: e.containerName, Command: command}
+	if stdin != nil {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return 0, err
+		}
+		cmd.Stdin = data
+	}
+	result, stubbed := e.run(cmd)
+	if !stubbed {
+		return 0, nil
+	}
+
+	if result.Delay > 0 {
+		select {
+		case <-time.After(result.Delay):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	if result.Err != nil {
+		return 0, result.Err
+	}
+	if stdout != nil {
+		_, _ = io.WriteString(stdout, result.Stdout)
+	}
+	if stderr != nil {
+		_, _ = io.WriteString(stderr, result.Stderr)
+	}
+	return result.ExitCode, nil
+}
+
+func (e *Executor) ExecCmd(ctx context.Context, command []string) (*pod.ExecResult, error) {
+	result := &pod.ExecResult{Command: command, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	var err error
+	if result.ExitCode, err = e.Exec(ctx, command, nil, result.Stdout, result.Stderr); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// run records cmd, applies it to the filesystem and returns its stub
+func (e *Executor) run(cmd Command) (Result, bool) {
+	e.pods.mu.Lock()
+	defer e.pods.mu.Unlock()
+	e.pods.commands = append(e.pods.commands, cmd)
+
+	// rm -f and xz -d are the only commands changing the filesystem. xz
+	// drops the extension of the file without decompressing it.
+	if len(cmd.Command) >= 3 && cmd.Command[0] == "rm" && cmd.Command[1] == "-f" {
+		for _, path := range cmd.Command[2:] {
+			delete(e.pods.files, e.fileKey(path))
+		}
+	}
+	if len(cmd.Command) >= 3 && cmd.Command[0] == "xz" && cmd.Command[1] == "-d" {
+		path := cmd.Command[len(cmd.Command)-1]
+		if data, ok := e.pods.files[e.fileKey(path)]; ok {
+			delete(e.pods.files, e.fileKey(path))
+			e.pods.files[e.fileKey(strings.TrimSuffix(path, ".xz"))] = data
+		}
+	}
+
+	var found *stub
+	for i, s := range e.pods.stubs {
+		if strings.Contains(cmd.String(), s.match) && (found == nil || len(s.match) > len(found.match)) {
+			found = &e.pods.stubs[i]
+		}
+	}
+	if found == nil {
+		return Result{}, false
+	}
+	return found.result, true
+}
+
+func (e *Executor) File(ctx context.Context, path string) pod.File {
+	return &file{ctx: ctx, executor: e, path: path}
+}
+
+func (e *Executor) fileKey(path string) string {
+	return fileKey(e.namespace, e.podName, e.containerName, path)
+}
+
+type file struct {
+	ctx      context.Context
+	executor *Executor
+	path     string
+	offset   int64
+}
+
+func (f *file) Write(b []byte) (int, error) {
+	if err := f.ctx.Err(); err != nil {
+		return 0, err
+	}
+	pods := f.executor.pods
+	pods.mu.Lock()
+	defer pods.mu.Unlock()
+	if pods.writeError != nil {
+		return 0, pods.writeError
+	}
+	key := f.executor.fileKey(f.path)
+	pods.files[key] = append(pods.files[key], b...)
+	return len(b), nil
+}
+
+func (f *file) Read(b []byte) (int, error) {
+	n, err := f.ReadAt(b, f.offset)
+	f.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *file) ReadAt(b []byte, off int64) (int, error) {
+	if err := f.ctx.Err(); err != nil {
+		return 0, err
+	}
+	data, ok := f.data()
+	if !ok {
+		return 0, &os.PathError{Op: "read", Path: f.path, Err: os.ErrNotExist}
+	}
+	if off >= int64(len(data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		data, _ := f.data()
+		offset += int64(len(data))
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *file) Stat() (os.FileInfo, error) {
+	data, ok := f.data()
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: f.path, Err: os.ErrNotExist}
+	}
+	return &fileInfo{name: path.Base(f.path), size: int64(len(data))}, nil
+}
+
+func (f *file) Close() error {
+	return nil
+}
+
+func (f *file) data() ([]byte, bool) {
+	pods := f.executor.pods
+	pods.mu.Lock()
+	defer pods.mu.Unlock()
+	data, ok := pods.files[f.executor.fileKey(f.path)]
+	return data, ok
+}
+
+// fileInfo of a regular file with mode 0644
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() os.FileMode  { return 0644 }
+func (fi *fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi *fileInfo) IsDir() bool        { return false }
+func (fi *fileInfo) Sys() interface{}   { return nil }
@@ -1,19 +1,53 @@
 package pod
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/engine"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 )
 
-func CreatePodSpec(backupClaimNewPod backupsv1beta1.BackupClaimNewPodDestinationSpec, namespace string) corev1.Pod {
-	return corev1.Pod{
+// Names generated by the API server are at most 63 characters, the last 5 are
+// random
+const maxGenerateNameLength = 58
+
+// PodGenerateName returns the prefix of the name of the pod of a claim: the
+// prefix of the destination, the name of the claim and a hash of its UID, so
+// claims sharing a prefix get their own pods
+func PodGenerateName(backupClaim *backupsv1beta1.BackupClaim) string {
+	sum := sha256.Sum256([]byte(backupClaim.UID))
+	hash := hex.EncodeToString(sum[:4])
+	base := backupClaim.Spec.Destination.Pod.NamePrefix + "-" + backupClaim.Name
+	if max := maxGenerateNameLength - len(hash) - 2; len(base) > max {
+		base = strings.TrimRight(base[:max], "-.")
+	}
+	return base + "-" + hash + "-"
+}
+
+// CreatePodSpec returns the pod of the new pod destination of backupClaim: the
+// defaults of the engine with the template of the destination merged onto
+// them. The user of the database comes from the secret credentialsSecret,
+// root can only connect from inside the pod.
+func CreatePodSpec(backupClaim *backupsv1beta1.BackupClaim, credentialsSecret string) (corev1.Pod, error) {
+	backupClaimNewPod := backupClaim.Spec.Destination.Pod
+	readiness, err := engine.ReadinessCommand(engine.MySQL)
+	if err != nil {
+		return corev1.Pod{}, err
+	}
+	newPod := corev1.Pod{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        backupClaimNewPod.NamePrefix + "-backupclaim",
-			Namespace:   namespace,
-			Labels:      make(map[string]string),
-			Annotations: make(map[string]string),
+			GenerateName: PodGenerateName(backupClaim),
+			Namespace:    backupClaim.Namespace,
+			Labels:       make(map[string]string),
+			Annotations:  make(map[string]string),
 		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
@@ -25,6 +59,24 @@ func CreatePodSpec(backupClaimNewPod backupsv1beta1.BackupClaimNewPodDestination
 							Name:  "MYSQL_ALLOW_EMPTY_PASSWORD",
 							Value: "true",
 						},
+						{
+							Name:  "MYSQL_ROOT_HOST",
+							Value: "localhost",
+						},
+						secretEnv("MYSQL_USER", credentialsSecret, CredentialsUsernameKey),
+						secretEnv("MYSQL_PASSWORD", credentialsSecret, CredentialsPasswordKey),
+					},
+					Ports: []corev1.ContainerPort{
+						{Name: "mysql", ContainerPort: MySQLPort, Protocol: corev1.ProtocolTCP},
+					},
+					// The server restarts once initialized, only
+					// ready when it accepts connections
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{Command: readiness},
+						},
+						InitialDelaySeconds: 5,
+						PeriodSeconds:       5,
 					},
 					Resources: backupClaimNewPod.Resources,
 				},
@@ -32,4 +84,66 @@ func CreatePodSpec(backupClaimNewPod backupsv1beta1.BackupClaimNewPodDestination
 			ImagePullSecrets: []corev1.LocalObjectReference{corev1.LocalObjectReference{Name: "awsecr-cred"}},
 		},
 	}
+	if backupClaimNewPod.Template == nil {
+		return newPod, nil
+	}
+	return mergeTemplate(newPod, backupClaimNewPod.Template)
+}
+
+func secretEnv(name, secret, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// mergeTemplate merges template onto p by strategic merge, the way kubectl
+// applies a patch
+func mergeTemplate(p corev1.Pod, template *backupsv1beta1.BackupClaimPodTemplateSpec) (corev1.Pod, error) {
+	override := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      template.Labels,
+			Annotations: template.Annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:         p.Spec.Containers[0].Name,
+					Image:        template.Image,
+					Env:          template.Env,
+					EnvFrom:      template.EnvFrom,
+					VolumeMounts: template.VolumeMounts,
+				},
+			},
+			Volumes:            template.Volumes,
+			NodeSelector:       template.NodeSelector,
+			Tolerations:        template.Tolerations,
+			Affinity:           template.Affinity,
+			SecurityContext:    template.SecurityContext,
+			ImagePullSecrets:   template.ImagePullSecrets,
+			ServiceAccountName: template.ServiceAccountName,
+		},
+	}
+	original, err := json.Marshal(p)
+	if err != nil {
+		return p, fmt.Errorf("could not marshal pod: %v", err)
+	}
+	patch, err := json.Marshal(override)
+	if err != nil {
+		return p, fmt.Errorf("could not marshal pod template: %v", err)
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, corev1.Pod{})
+	if err != nil {
+		return p, fmt.Errorf("could not merge pod template: %v", err)
+	}
+	var result corev1.Pod
+	if err := json.Unmarshal(merged, &result); err != nil {
+		return p, fmt.Errorf("could not unmarshal merged pod: %v", err)
+	}
+	return result, nil
 }
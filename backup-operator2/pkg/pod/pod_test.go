@@ -0,0 +1,121 @@
+package pod
+
+import (
+	"strings"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestCreatePodSpecTemplate(t *testing.T) {
+	claim := &backupsv1beta1.BackupClaim{}
+	claim.Namespace = "team"
+	claim.Name = "app"
+	claim.Spec.Destination.Pod = backupsv1beta1.BackupClaimNewPodDestinationSpec{
+		NamePrefix: "restore",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		Template: &backupsv1beta1.BackupClaimPodTemplateSpec{
+			Labels: map[string]string{"team": "crm"},
+			Image:  "registry.example.com/mysql:8.0",
+			Env: []corev1.EnvVar{
+				{Name: "MYSQL_ALLOW_EMPTY_PASSWORD", Value: "false"},
+				{Name: "TZ", Value: "UTC"},
+			},
+			VolumeMounts:       []corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp"}},
+			Volumes:            []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			NodeSelector:       map[string]string{"pool": "restore"},
+			Tolerations:        []corev1.Toleration{{Key: "restore", Operator: corev1.TolerationOpExists}},
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+			ServiceAccountName: "restore",
+		},
+	}
+	p, err := CreatePodSpec(claim, "app-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.GenerateName != "restore-app-e3b0c442-" || p.Namespace != "team" || p.Labels["team"] != "crm" {
+		t.Errorf("unexpected metadata %+v", p.ObjectMeta)
+	}
+	if len(p.Spec.Containers) != 1 {
+		t.Fatalf("the template should be merged onto the database container, got %+v", p.Spec.Containers)
+	}
+	container := p.Spec.Containers[0]
+	if container.Name != "mysql" || container.Image != "registry.example.com/mysql:8.0" {
+		t.Errorf("unexpected container %s with image %s", container.Name, container.Image)
+	}
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	if len(env) != 5 || env["MYSQL_ALLOW_EMPTY_PASSWORD"] != "false" || env["TZ"] != "UTC" {
+		t.Errorf("env should be merged by name, got %v", container.Env)
+	}
+	if memory := container.Resources.Requests[corev1.ResourceMemory]; memory.String() != "1Gi" {
+		t.Errorf("resources should be kept, got %v", container.Resources)
+	}
+	if len(container.VolumeMounts) != 1 || len(p.Spec.Volumes) != 1 || p.Spec.Volumes[0].EmptyDir == nil {
+		t.Errorf("unexpected volumes %v mounted at %v", p.Spec.Volumes, container.VolumeMounts)
+	}
+	if len(p.Spec.ImagePullSecrets) != 2 {
+		t.Errorf("pull secrets should be merged by name, got %v", p.Spec.ImagePullSecrets)
+	}
+	if p.Spec.NodeSelector["pool"] != "restore" || len(p.Spec.Tolerations) != 1 || p.Spec.ServiceAccountName != "restore" {
+		t.Errorf("unexpected scheduling %v %v %s", p.Spec.NodeSelector, p.Spec.Tolerations, p.Spec.ServiceAccountName)
+	}
+}
+
+func TestCreatePodSpecDefaults(t *testing.T) {
+	claim := &backupsv1beta1.BackupClaim{}
+	claim.Spec.Destination.Pod.NamePrefix = "app"
+	p, err := CreatePodSpec(claim, "app-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Spec.Containers[0].Image != "mysql:latest" || p.Spec.ImagePullSecrets[0].Name != "awsecr-cred" {
+		t.Errorf("unexpected defaults %+v", p.Spec)
+	}
+}
+
+func TestPodGenerateName(t *testing.T) {
+	claim := &backupsv1beta1.BackupClaim{}
+	claim.Name = "app"
+	claim.UID = "0b7e8f4c-3d1a-4a38-9d2b-6c5f1e2a7b90"
+	claim.Spec.Destination.Pod.NamePrefix = "restore"
+	name := PodGenerateName(claim)
+	other := claim.DeepCopy()
+	other.UID = "5d0c2a9e-8f61-4b7d-a3e4-1f9b6c8d2e07"
+	if !strings.HasPrefix(name, "restore-app-") || name == PodGenerateName(other) {
+		t.Errorf("claims sharing a prefix should get their own names, got %s and %s", name, PodGenerateName(other))
+	}
+
+	claim.Name = strings.Repeat("a", 60)
+	long := PodGenerateName(claim)
+	if len(long) > maxGenerateNameLength || !strings.HasSuffix(long, name[len(name)-10:]) {
+		t.Errorf("long names should be truncated before the hash, got %s", long)
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	if name := ServiceName("app"); name != "app-db" {
+		t.Errorf("valid names should be kept, got %s", name)
+	}
+	for _, claimName := range []string{
+		strings.Repeat("a", 61),
+		"app.v2",
+		"2021-12-01",
+	} {
+		name := ServiceName(claimName)
+		if errs := validation.IsDNS1035Label(name); len(errs) != 0 {
+			t.Errorf("%s: the service name %s should be a DNS-1035 label: %v", claimName, name, errs)
+		}
+		if name == ServiceName(claimName+"b") {
+			t.Errorf("%s: shortened names should stay unique, got %s", claimName, name)
+		}
+	}
+}
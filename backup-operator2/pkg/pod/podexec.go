@@ -2,16 +2,23 @@ package pod
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
-	"k8s.io/kubectl/pkg/cmd/exec"
-	"os"
+	utilexec "k8s.io/client-go/util/exec"
+	"regexp"
+	"strings"
+	"time"
 )
 
 type PodExec struct {
@@ -20,92 +27,110 @@ type PodExec struct {
 	Namespace     string
 	PodName       string
 	ContainerName string
+	pod           *corev1.Pod
 }
 
-func NewPodExec(config rest.Config, clientset *kubernetes.Clientset, namespace, podname, containername string) *PodExec {
+// How long killing the command of a canceled exec session may take
+const killTimeout = 10 * time.Second
+
+// NewPodExec returns the executor of a container of pod. The first container
+// is used when containername is empty.
+func NewPodExec(config rest.Config, clientset *kubernetes.Clientset, pod *corev1.Pod, containername string) *PodExec {
 	config.APIPath = "/api"
 	config.GroupVersion = &schema.GroupVersion{Version: "v1"}
 	config.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs}
+	if containername == "" && len(pod.Spec.Containers) > 0 {
+		containername = pod.Spec.Containers[0].Name
+	}
 	return &PodExec{
 		RestConfig:    &config,
 		Clientset:     clientset,
-		Namespace:     namespace,
-		PodName:       podname,
+		Namespace:     pod.Namespace,
+		PodName:       pod.Name,
 		ContainerName: containername,
+		pod:           pod,
+	}
+}
+
+// Exec runs command in the container with the given streams and returns its
+// exit code. The session uses WebSockets, or SPDY when the API server or a
+// proxy in front of it refuses WebSockets. It is closed when ctx is done, and
+// the command killed if the container has pkill.
+func (p *PodExec) Exec(ctx context.Context, command []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	code, err := p.exec(ctx, command, stdin, stdout, stderr)
+	if ctx.Err() != nil {
+		p.kill(command)
+	}
+	return code, err
+}
+
+func (p *PodExec) exec(ctx context.Context, command []string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	if p.pod.Status.Phase == corev1.PodSucceeded || p.pod.Status.Phase == corev1.PodFailed {
+		return 0, fmt.Errorf("cannot exec into a container in a completed pod; current phase is %s", p.pod.Status.Phase)
+	}
+	req := p.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(p.Namespace).
+		Name(p.PodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: p.ContainerName,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	err := stream(ctx, p.RestConfig, req.URL(), stdin, stdout, stderr)
+	// The exec stream reports a non-zero exit code as an error
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), nil
 	}
+	return 0, err
 }
 
 // ExecCmd
 // EXAMPLES
 // // Execute ls -l /tmp on container test-container on pod test in namespace default
 // // and print the resulting output
-// in, out, errOut, err := podExec.ExecCmd([]string{"ls", "-l", "/tmp"}, "default", "test", "test-container")
+// result, err := pod.MustSucceed(podExec.ExecCmd(ctx, []string{"ls", "-l", "/tmp"}))
 // if err != nil {
 //		fmt.Printf("%v\n", err)
 //	}
 //	fmt.Println("out:")
-//	fmt.Printf("%s", out.String()) // will execute ls -l /tmp in the pod and output the result
-func (p *PodExec) ExecCmd(command []string) (*bytes.Buffer, *bytes.Buffer, *bytes.Buffer, error) {
-	ioStreams, in, out, errOut := genericclioptions.NewTestIOStreams()
-	options := &exec.ExecOptions{
-		StreamOptions: exec.StreamOptions{
-			Namespace:       p.Namespace,
-			PodName:         p.PodName,
-			ContainerName:   p.ContainerName,
-			Stdin:           true,
-			TTY:             false,
-			Quiet:           false,
-			InterruptParent: nil,
-			IOStreams:       ioStreams,
-		},
-		Command:       command,
-		Executor:      &exec.DefaultRemoteExecutor{},
-		PodClient:     p.Clientset.CoreV1(),
-		GetPodTimeout: 0,
-		Config:        p.RestConfig,
-	}
+//	fmt.Printf("%s", result.Stdout.String()) // will execute ls -l /tmp in the pod and output the result
+func (p *PodExec) ExecCmd(ctx context.Context, command []string) (result *ExecResult, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "PodExec.ExecCmd", trace.WithAttributes(
+		attribute.String("pod.namespace", p.Namespace),
+		attribute.String("pod.name", p.PodName),
+		attribute.String("pod.container", p.ContainerName),
+		// Only the program, arguments may hold credentials
+		attribute.String("exec.command", command[0]),
+	))
+	defer func() {
+		if result != nil {
+			span.SetAttributes(attribute.Int("exec.exit_code", result.ExitCode))
+		}
+		tracing.End(span, err)
+	}()
 
-	err := options.Run()
+	result = &ExecResult{Command: command, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	start := time.Now()
+	result.ExitCode, err = p.Exec(ctx, command, nil, result.Stdout, result.Stderr)
+	result.Duration = time.Since(start)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("Could not run exec operation: %v", err)
+		return nil, fmt.Errorf("Could not run exec operation: %v", err)
 	}
-
-	return in, out, errOut, nil
+	return result, nil
 }
 
-func (p *PodExec) UploadFileTwo(config *rest.Config, clientset *kubernetes.Clientset, path string) ([]byte, error) {
-	options := &exec.ExecOptions{}
-	out := bytes.NewBuffer([]byte{})
-	errOut := bytes.NewBuffer([]byte{})
-	f, _ := os.Open(path)
-	reader, writer := io.Pipe()
-
-	go func(f *os.File, writer *io.PipeWriter) {
-		defer writer.Close()
-		_, _ = io.Copy(writer, f)
-	}(f, writer)
-
-	options.StreamOptions = exec.StreamOptions{
-		IOStreams: genericclioptions.IOStreams{
-			In:     reader,
-			Out:    out,
-			ErrOut: errOut,
-		},
-		Stdin:     true,
-		Namespace: "default",
-		PodName:   "cron-test",
-	}
-	options.Executor = &exec.DefaultRemoteExecutor{}
-	options.Namespace = "default"
-	options.PodName = "cron-test"
-	options.ContainerName = "test-container"
-	options.Config = config
-	options.PodClient = clientset.CoreV1()
-	options.Command = []string{"/bin/cp", "/dev/stdin", "/tmp/outfile"}
-
-	err := options.Run()
-	if err != nil {
-		return nil, err
-	}
-	return nil, nil
+// kill kills the processes running command, left behind by a closed exec
+// session. It is best effort: the error is ignored, as containers without
+// pkill can not do better.
+func (p *PodExec) kill(command []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	defer cancel()
+	pattern := regexp.QuoteMeta(strings.Join(command, " "))
+	_, _ = p.exec(ctx, []string{"pkill", "-KILL", "-x", "-f", pattern}, nil, nil, io.Discard)
 }
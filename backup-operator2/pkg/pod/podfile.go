@@ -2,22 +2,43 @@ package pod
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/kubectl/pkg/cmd/exec"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // PodFile
-// Implement Read and Write interface
+// Implement Read, ReadAt, Seek and Write interface. Writes are appended to
+// Path. Reads stream Path from the offset set by Seek, using tail, and ReadAt
+// reads a range using dd. Reads and writes fail once ctx is done.
 type PodFile struct {
 	Path string
 	*PodExec
+	ctx    context.Context
+	offset int64
+	stream *fileStream
 }
 
-func NewPodFile(path string, podexec *PodExec) *PodFile {
+// fileStream is the download read by Read, stopped by Seek and Close
+type fileStream struct {
+	r      *io.PipeReader
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Size of the blocks read by dd
+const ddBlockSize = 64 * 1024
+
+func NewPodFile(ctx context.Context, path string, podexec *PodExec) *PodFile {
 	return &PodFile{
 		Path:    path,
 		PodExec: podexec,
+		ctx:     ctx,
 	}
 }
 
@@ -30,79 +51,225 @@ func (pf *PodFile) Write(b []byte) (n int, err error) {
 	return len(b), nil
 }
 
-// Read from Path to b []byte
+// Read from Path to b []byte. The file is downloaded from the offset by a
+// single command, read by the following calls until io.EOF.
 func (pf *PodFile) Read(b []byte) (n int, err error) {
-	buf := bytes.NewBuffer([]byte{})
-	written, err := pf.downloadFile(buf)
+	if pf.stream == nil {
+		pf.stream = pf.openStream(pf.offset)
+	}
+	n, err = pf.stream.r.Read(b)
+	pf.offset += int64(n)
 	if err != nil {
+		pf.closeStream()
+	}
+	return n, err
+}
+
+// ReadAt reads len(b) bytes of Path starting at off. It returns io.EOF when
+// the file ends before b is full. The offset of Read is not changed.
+func (pf *PodFile) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("could not read '%s': negative offset %d", pf.Path, off)
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(b)))
+	if _, err := pf.downloadFile(pf.ctx, buf, off, int64(len(b))); err != nil {
 		return 0, err
 	}
-	copy(b, buf.Bytes())
-	return int(written), io.EOF
+	n = copy(b, buf.Bytes())
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Seek sets the offset of the next Read. A running download is stopped when
+// the offset changes.
+func (pf *PodFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += pf.offset
+	case io.SeekEnd:
+		info, err := pf.Stat()
+		if err != nil {
+			return 0, err
+		}
+		offset += info.Size()
+	default:
+		return 0, fmt.Errorf("could not seek '%s': invalid whence %d", pf.Path, whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("could not seek '%s': negative offset %d", pf.Path, offset)
+	}
+	if offset != pf.offset {
+		pf.closeStream()
+		pf.offset = offset
+	}
+	return offset, nil
+}
+
+// Stat returns the size, mode and modification time of Path. The error
+// satisfies os.IsNotExist when Path does not exist.
+func (pf *PodFile) Stat() (os.FileInfo, error) {
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	code, err := pf.Exec(pf.ctx, []string{"stat", "-L", "-c", "%s %f %Y", pf.Path}, nil, out, errOut)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat '%s': %v", pf.Path, err)
+	}
+	if code != 0 {
+		stderr := tail(errOut.String(), stderrTailLines)
+		if strings.Contains(stderr, "No such file or directory") {
+			return nil, &os.PathError{Op: "stat", Path: pf.Path, Err: os.ErrNotExist}
+		}
+		return nil, fmt.Errorf("could not stat '%s': %s", pf.Path, stderr)
+	}
+	return parseStat(pf.Path, out.String())
+}
+
+// Close stops the download of Read. Writes are not buffered, they need no
+// closing.
+func (pf *PodFile) Close() error {
+	pf.closeStream()
+	return nil
+}
+
+func (pf *PodFile) openStream(offset int64) *fileStream {
+	ctx, cancel := context.WithCancel(pf.ctx)
+	r, w := io.Pipe()
+	stream := &fileStream{r: r, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(stream.done)
+		_, err := pf.downloadFile(ctx, w, offset, -1)
+		w.CloseWithError(err)
+	}()
+	return stream
+}
+
+func (pf *PodFile) closeStream() {
+	if pf.stream == nil {
+		return
+	}
+	pf.stream.cancel()
+	pf.stream.r.Close()
+	<-pf.stream.done
+	pf.stream = nil
 }
 
 func (pf *PodFile) uploadFile(r io.Reader) error {
-	options := &exec.ExecOptions{}
-	out := bytes.NewBuffer([]byte{})
 	errOut := bytes.NewBuffer([]byte{})
-	reader, writer := io.Pipe()
-
-	go func(r io.Reader, writer *io.PipeWriter) {
-		defer writer.Close()
-		_, _ = io.Copy(writer, r)
-	}(r, writer)
-
-	options.StreamOptions = exec.StreamOptions{
-		IOStreams: genericclioptions.IOStreams{
-			In:     reader,
-			Out:    out,
-			ErrOut: errOut,
-		},
-		Stdin:     true,
-		Namespace: pf.Namespace,
-		PodName:   pf.PodName,
-	}
-	options.Executor = &exec.DefaultRemoteExecutor{}
-	options.Namespace = pf.Namespace
-	options.PodName = pf.PodName
-	options.ContainerName = pf.ContainerName
-	options.Config = pf.RestConfig
-	options.PodClient = pf.Clientset.CoreV1()
-	options.Command = []string{"tee", "-a", pf.Path}
-
-	err := options.Run()
+	code, err := pf.Exec(pf.ctx, []string{"tee", "-a", pf.Path}, r, nil, errOut)
+	if err == nil && code != 0 {
+		err = fmt.Errorf("tee exited with code %d", code)
+	}
 	if err != nil {
+		if stderr := tail(errOut.String(), stderrTailLines); stderr != "" {
+			return fmt.Errorf("%v: %s", err, stderr)
+		}
 		return err
 	}
 	return nil
 }
 
-func (pf *PodFile) downloadFile(w io.Writer) (int64, error) {
-	options := &exec.ExecOptions{}
+// downloadFile writes length bytes of Path starting at offset to w, or up to
+// the end of the file when length is negative
+func (pf *PodFile) downloadFile(ctx context.Context, w io.Writer, offset, length int64) (int64, error) {
+	command := []string{"tail", "-c", fmt.Sprintf("+%d", offset+1), pf.Path}
+	if length >= 0 {
+		command = []string{"dd", "if=" + pf.Path, "iflag=skip_bytes,count_bytes",
+			fmt.Sprintf("skip=%d", offset), fmt.Sprintf("count=%d", length),
+			fmt.Sprintf("bs=%d", ddBlockSize), "status=none"}
+	}
+	out := &countingWriter{w: w}
 	errOut := bytes.NewBuffer([]byte{})
-	reader, writer := io.Pipe()
-
-	options.StreamOptions = exec.StreamOptions{
-		IOStreams: genericclioptions.IOStreams{
-			In:     nil,
-			Out:    writer,
-			ErrOut: errOut,
-		},
-		Namespace: pf.Namespace,
-		PodName:   pf.PodName,
-	}
-	options.Executor = &exec.DefaultRemoteExecutor{}
-	options.Namespace = pf.Namespace
-	options.PodName = pf.PodName
-	options.ContainerName = pf.ContainerName
-	options.Config = pf.PodExec.RestConfig
-	options.PodClient = pf.PodExec.Clientset.CoreV1()
-	options.Command = []string{"/bin/cp", pf.Path, "/dev/stdout"}
-
-	go func(options *exec.ExecOptions, writer *io.PipeWriter) {
-		defer writer.Close()
-		_ = options.Run()
-	}(options, writer)
-
-	return io.Copy(w, reader)
+	code, err := pf.Exec(ctx, command, nil, out, errOut)
+	if err == nil && code != 0 {
+		err = fmt.Errorf("could not read '%s': %s", pf.Path, tail(errOut.String(), stderrTailLines))
+	}
+	return out.n, err
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
+
+// fileInfo of a file of a container
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+// parseStat parses the output of stat -c '%s %f %Y': the size, the raw mode
+// in hex and the modification time in seconds since the epoch
+func parseStat(filepath, out string) (os.FileInfo, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("could not stat '%s': unexpected output '%s'", filepath, out)
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat '%s': invalid size: %v", filepath, err)
+	}
+	raw, err := strconv.ParseUint(fields[1], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat '%s': invalid mode: %v", filepath, err)
+	}
+	mtime, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not stat '%s': invalid modification time: %v", filepath, err)
+	}
+	return &fileInfo{
+		name:    path.Base(filepath),
+		size:    size,
+		mode:    fileMode(uint32(raw)),
+		modTime: time.Unix(mtime, 0),
+	}, nil
+}
+
+// fileMode converts the st_mode of stat to an os.FileMode
+func fileMode(raw uint32) os.FileMode {
+	mode := os.FileMode(raw & 0777)
+	switch raw & 0170000 {
+	case 0040000:
+		mode |= os.ModeDir
+	case 0120000:
+		mode |= os.ModeSymlink
+	case 0010000:
+		mode |= os.ModeNamedPipe
+	case 0140000:
+		mode |= os.ModeSocket
+	case 0020000:
+		mode |= os.ModeDevice | os.ModeCharDevice
+	case 0060000:
+		mode |= os.ModeDevice
+	}
+	if raw&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if raw&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if raw&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
 }
@@ -0,0 +1,229 @@
+package pod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Channels of the exec sessions over WebSockets, every message starts with
+// the channel it belongs to
+const (
+	wsStdin  = 0
+	wsStdout = 1
+	wsStderr = 2
+	wsError  = 3
+	// Closes a channel
+	wsClose = 255
+)
+
+// localExec serves the exec subresource over WebSockets by running the
+// commands on this machine, so the commands of PodFile and of the directory
+// copies can be tested
+func localExec(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"v5.channel.k8s.io"}}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	query := r.URL.Query()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, query["command"][0], query["command"][1:]...)
+	var mu sync.Mutex
+	cmd.Stdout = &channelWriter{conn: conn, mu: &mu, channel: wsStdout}
+	cmd.Stderr = &channelWriter{conn: conn, mu: &mu, channel: wsStderr}
+	stdin, _ := cmd.StdinPipe()
+	if err := cmd.Start(); err != nil {
+		return
+	}
+
+	go func() {
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				// The client went away
+				cancel()
+				return
+			}
+			if msg[0] == wsClose {
+				stdin.Close()
+			} else if msg[0] == wsStdin {
+				_, _ = stdin.Write(msg[1:])
+			}
+		}
+	}()
+	if query.Get("stdin") != "true" {
+		stdin.Close()
+	}
+
+	status := metav1.Status{Status: metav1.StatusSuccess}
+	if err := cmd.Wait(); err != nil {
+		status = metav1.Status{
+			Status: metav1.StatusFailure,
+			Reason: remotecommand.NonZeroExitCodeReason,
+			Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{
+				{Type: remotecommand.ExitCodeCauseType, Message: strconv.Itoa(cmd.ProcessState.ExitCode())},
+			}},
+		}
+	}
+	data, _ := json.Marshal(status)
+	errStream := &channelWriter{conn: conn, mu: &mu, channel: wsError}
+	_, _ = errStream.Write(data)
+	mu.Lock()
+	defer mu.Unlock()
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// channelWriter writes to a channel of a WebSocket shared with the other
+// channels
+type channelWriter struct {
+	conn    *websocket.Conn
+	mu      *sync.Mutex
+	channel byte
+}
+
+func (w *channelWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.conn.WriteMessage(websocket.BinaryMessage, append([]byte{w.channel}, b...)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// newLocalPodExec returns a PodExec running its commands on this machine
+func newLocalPodExec(t *testing.T) *PodExec {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			http.Error(w, "spdy is not supported", http.StatusBadRequest)
+			return
+		}
+		localExec(w, r)
+	}))
+	t.Cleanup(server.Close)
+	config := &rest.Config{Host: server.URL}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "database"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "mysql"}}},
+	}
+	return NewPodExec(*config, clientset, pod, "")
+}
+
+func newLocalPodFile(t *testing.T, path string) *PodFile {
+	return NewPodFile(context.Background(), path, newLocalPodExec(t))
+}
+
+func TestPodFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.sql")
+	podFile := newLocalPodFile(t, path)
+	defer podFile.Close()
+
+	for _, chunk := range []string{"01234567", "89abcdef"} {
+		if _, err := podFile.Write([]byte(chunk)); err != nil {
+			t.Fatalf("could not write: %v", err)
+		}
+	}
+
+	info, err := podFile.Stat()
+	if err != nil {
+		t.Fatalf("could not stat: %v", err)
+	}
+	if info.Name() != "backup.sql" || info.Size() != 16 || !info.Mode().IsRegular() || info.ModTime().IsZero() {
+		t.Errorf("unexpected file info %s %d %s %s", info.Name(), info.Size(), info.Mode(), info.ModTime())
+	}
+
+	// Reads smaller than the file are not truncated
+	data := &bytes.Buffer{}
+	buf := make([]byte, 5)
+	for {
+		n, err := podFile.Read(buf)
+		data.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("could not read: %v", err)
+		}
+	}
+	if data.String() != "0123456789abcdef" {
+		t.Errorf("unexpected content '%s'", data)
+	}
+
+	buf = make([]byte, 4)
+	if n, err := podFile.ReadAt(buf, 10); err != nil || string(buf[:n]) != "abcd" {
+		t.Errorf("unexpected ReadAt '%s', %v", buf[:n], err)
+	}
+	if n, err := podFile.ReadAt(buf, 14); err != io.EOF || string(buf[:n]) != "ef" {
+		t.Errorf("unexpected ReadAt at the end '%s', %v", buf[:n], err)
+	}
+
+	if offset, err := podFile.Seek(-4, io.SeekEnd); err != nil || offset != 12 {
+		t.Fatalf("unexpected Seek %d, %v", offset, err)
+	}
+	if n, err := io.ReadFull(podFile, buf); err != nil || string(buf[:n]) != "cdef" {
+		t.Errorf("unexpected Read after Seek '%s', %v", buf[:n], err)
+	}
+}
+
+func TestPodFileClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.sql")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("0123456789abcdef"), 64*1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	podFile := newLocalPodFile(t, path)
+
+	buf := make([]byte, 16)
+	if _, err := io.ReadFull(podFile, buf); err != nil || string(buf) != "0123456789abcdef" {
+		t.Fatalf("unexpected Read '%s', %v", buf, err)
+	}
+	// Stops the download of the rest of the file
+	if err := podFile.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if offset, err := podFile.Seek(0, io.SeekCurrent); err != nil || offset != 16 {
+		t.Errorf("unexpected offset %d, %v", offset, err)
+	}
+}
+
+func TestPodFileStatNotExist(t *testing.T) {
+	podFile := newLocalPodFile(t, filepath.Join(t.TempDir(), "missing.sql"))
+	if _, err := podFile.Stat(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the file to not exist, got %v", err)
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	for raw, expected := range map[uint32]os.FileMode{
+		0100644: 0644,
+		0040755: os.ModeDir | 0755,
+		0120777: os.ModeSymlink | 0777,
+		0104755: os.ModeSetuid | 0755,
+	} {
+		if mode := fileMode(raw); mode != expected {
+			t.Errorf("mode of %o should be %s, got %s", raw, expected, mode)
+		}
+	}
+}
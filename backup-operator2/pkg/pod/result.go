@@ -0,0 +1,71 @@
+package pod
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lines of stderr kept in the errors of failed commands
+const stderrTailLines = 10
+
+// ExecResult is the outcome of a command run in a container
+type ExecResult struct {
+	Command  []string
+	ExitCode int
+	Stdout   *bytes.Buffer
+	Stderr   *bytes.Buffer
+	Duration time.Duration
+}
+
+// Succeeded tells if the command exited with code 0
+func (r *ExecResult) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+// StderrTail returns the last lines of stderr, enough to explain a failure
+// without flooding the status of the claim
+func (r *ExecResult) StderrTail() string {
+	if r.Stderr == nil {
+		return ""
+	}
+	return tail(r.Stderr.String(), stderrTailLines)
+}
+
+// ExitError is returned by MustSucceed for commands exiting with a non-zero
+// code
+type ExitError struct {
+	Result *ExecResult
+}
+
+func (e *ExitError) Error() string {
+	msg := fmt.Sprintf("command '%s' exited with code %d", e.Result.Command[0], e.Result.ExitCode)
+	if stderr := e.Result.StderrTail(); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+// MustSucceed returns err if the command could not run, or an *ExitError if
+// it exited with a non-zero code. It wraps calls to ExecCmd:
+//
+//	result, err := pod.MustSucceed(executor.ExecCmd(ctx, []string{"xz", "-d", path}))
+func MustSucceed(result *ExecResult, err error) (*ExecResult, error) {
+	if err != nil {
+		return result, err
+	}
+	if !result.Succeeded() {
+		return result, &ExitError{Result: result}
+	}
+	return result, nil
+}
+
+// tail returns the last n lines of s, without the trailing newline
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,38 @@
+package pod
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMustSucceed(t *testing.T) {
+	ok := &ExecResult{Command: []string{"true"}, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	if _, err := MustSucceed(ok, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := MustSucceed(nil, errors.New("connection refused")); err == nil || err.Error() != "connection refused" {
+		t.Errorf("expected the error of the stream, got %v", err)
+	}
+
+	var stderr strings.Builder
+	for i := 1; i <= 15; i++ {
+		stderr.WriteString("line " + string(rune('a'+i)) + "\n")
+	}
+	failed := &ExecResult{Command: []string{"xz", "-d", "/tmp/dump.sql.xz"}, ExitCode: 1, Stdout: &bytes.Buffer{}, Stderr: bytes.NewBufferString(stderr.String())}
+	_, err := MustSucceed(failed, nil)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Result.ExitCode != 1 {
+		t.Fatalf("expected an ExitError, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "command 'xz' exited with code 1: ") {
+		t.Errorf("unexpected message '%s'", msg)
+	}
+	// Only the last lines of stderr are kept
+	if strings.Contains(msg, "line f\n") || !strings.HasSuffix(msg, "line g\nline h\nline i\nline j\nline k\nline l\nline m\nline n\nline o\nline p") {
+		t.Errorf("unexpected stderr in '%s'", msg)
+	}
+}
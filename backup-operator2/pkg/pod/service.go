@@ -0,0 +1,127 @@
+package pod
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// Port of the database of the new pod destination
+	MySQLPort = 3306
+
+	// Keys of the credentials secret, named to be consumed with envFrom
+	CredentialsHostKey     = "DB_HOST"
+	CredentialsPortKey     = "DB_PORT"
+	CredentialsDatabaseKey = "DB_DATABASE"
+	CredentialsUsernameKey = "DB_USERNAME"
+	CredentialsPasswordKey = "DB_PASSWORD"
+	CredentialsURLKey      = "DATABASE_URL"
+)
+
+// Services are named after DNS-1035 labels
+const maxServiceNameLength = validation.DNS1035LabelMaxLength
+
+// ServiceName is the name of the service of the pod of a claim. Claim names
+// which do not make a DNS-1035 label, too long or holding dots, are shortened
+// and suffixed with a hash of the name, so they stay unique.
+func ServiceName(claimName string) string {
+	name := claimName + "-db"
+	if len(validation.IsDNS1035Label(name)) == 0 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(claimName))
+	hash := hex.EncodeToString(sum[:4])
+	base := strings.ReplaceAll(claimName, ".", "-")
+	if base[0] < 'a' || base[0] > 'z' {
+		base = "db-" + base
+	}
+	if max := maxServiceNameLength - len(hash) - len("--db"); len(base) > max {
+		base = strings.TrimRight(base[:max], "-")
+	}
+	return base + "-" + hash + "-db"
+}
+
+// CredentialsSecretName is the name of the secret holding the credentials
+// of the database of a claim
+func CredentialsSecretName(claimName string) string {
+	return claimName + "-credentials"
+}
+
+// CreateServiceSpec returns a ClusterIP service in front of the database of
+// the pods matching selector
+func CreateServiceSpec(name, namespace string, selector map[string]string) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: selector,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "mysql",
+					Port:       MySQLPort,
+					TargetPort: intstr.FromString("mysql"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// CreateCredentialsSecret returns a secret holding a random user and password
+// of database, reachable at host
+func CreateCredentialsSecret(name, namespace, host, database string) (corev1.Secret, error) {
+	username, err := randomString(4)
+	if err != nil {
+		return corev1.Secret{}, err
+	}
+	password, err := randomString(16)
+	if err != nil {
+		return corev1.Secret{}, err
+	}
+	username = "restore_" + username
+	port := strconv.Itoa(MySQLPort)
+	uri := url.URL{
+		Scheme: "mysql",
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(host, port),
+		Path:   "/" + database,
+	}
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			CredentialsHostKey:     []byte(host),
+			CredentialsPortKey:     []byte(port),
+			CredentialsDatabaseKey: []byte(database),
+			CredentialsUsernameKey: []byte(username),
+			CredentialsPasswordKey: []byte(password),
+			CredentialsURLKey:      []byte(uri.String()),
+		},
+	}, nil
+}
+
+// randomString returns n random bytes, hex encoded
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate credentials: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,78 @@
+package pod
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// stream runs the exec session of url over WebSockets, falling back to SPDY
+// when the API server or a proxy in front of it refuses WebSockets. The
+// session is closed when ctx is done.
+func stream(ctx context.Context, config *rest.Config, url *url.URL, stdin io.Reader, stdout, stderr io.Writer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	websocketExec, err := remotecommand.NewWebSocketExecutor(config, http.MethodGet, url.String())
+	if err != nil {
+		return err
+	}
+	spdyExec, err := remotecommand.NewSPDYExecutor(config, http.MethodPost, url)
+	if err != nil {
+		return err
+	}
+	exec, err := remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return err
+	}
+	options := remotecommand.StreamOptions{Stdin: stdin}
+	// The output of a cancelled session may still be copied once
+	// StreamWithContext returned, stop writing to the caller then
+	if stdout != nil {
+		out := &guardedWriter{w: stdout}
+		defer out.close()
+		options.Stdout = out
+	}
+	if stderr != nil {
+		errOut := &guardedWriter{w: stderr}
+		defer errOut.close()
+		options.Stderr = errOut
+	}
+	err = exec.StreamWithContext(ctx, options)
+	// The stream fails with a closed connection, report why it was closed
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// guardedWriter writes to w until closed, then drops the writes
+type guardedWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closed bool
+}
+
+func (g *guardedWriter) Write(b []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return 0, io.ErrClosedPipe
+	}
+	return g.w.Write(b)
+}
+
+// close waits for the ongoing write, if any, and drops the next ones
+func (g *guardedWriter) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed = true
+}
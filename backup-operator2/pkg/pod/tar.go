@@ -0,0 +1,277 @@
+package pod
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Progress is called while a directory is copied with the file being copied,
+// relative to the directory, and the bytes of the files copied so far
+type Progress func(path string, copied int64)
+
+// CopyDirToPod copies the local directory src to the directory dest of the
+// container, like kubectl cp, by streaming a tar archive to tar in the
+// container. dest is created if it does not exist. Permissions are preserved,
+// the files are owned by the user running tar. progress may be nil.
+func CopyDirToPod(ctx context.Context, e Executor, src, dest string, progress Progress) error {
+	if _, err := MustSucceed(e.ExecCmd(ctx, []string{"mkdir", "-p", dest})); err != nil {
+		return fmt.Errorf("could not create directory '%s' in pod: %v", dest, err)
+	}
+
+	r, w := io.Pipe()
+	archived := make(chan error, 1)
+	go func() {
+		err := writeTar(w, src, progress)
+		w.CloseWithError(err)
+		archived <- err
+	}()
+	errOut := &bytes.Buffer{}
+	code, err := e.Exec(ctx, []string{"tar", "-x", "-p", "-o", "-f", "-", "-C", dest}, r, nil, errOut)
+	// Unblocks the archive when tar stopped reading it
+	r.Close()
+	if tarErr := <-archived; tarErr != nil && !errors.Is(tarErr, io.ErrClosedPipe) {
+		return fmt.Errorf("could not archive '%s': %v", src, tarErr)
+	}
+	if err != nil {
+		return fmt.Errorf("could not copy '%s' to '%s' in pod: %v", src, dest, err)
+	}
+	if code != 0 {
+		return fmt.Errorf("could not copy '%s' to '%s' in pod: tar exited with code %d: %s", src, dest, code, tail(errOut.String(), stderrTailLines))
+	}
+	return nil
+}
+
+// CopyDirFromPod copies the directory src of the container to the local
+// directory dest, by streaming a tar archive from tar in the container. dest
+// is created if it does not exist. Permissions and modification times are
+// preserved. Entries escaping dest, links pointing outside of dest and
+// entries written through links are refused. progress may be nil.
+func CopyDirFromPod(ctx context.Context, e Executor, src, dest string, progress Progress) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r, w := io.Pipe()
+	extracted := make(chan error, 1)
+	go func() {
+		err := readTar(r, dest, progress)
+		if err != nil {
+			// Stops tar in the container
+			cancel()
+		} else {
+			// tar pads the archive after its end
+			_, _ = io.Copy(io.Discard, r)
+		}
+		r.CloseWithError(err)
+		extracted <- err
+	}()
+	errOut := &bytes.Buffer{}
+	code, err := e.Exec(ctx, []string{"tar", "-c", "-f", "-", "-C", src, "."}, nil, w, errOut)
+	w.Close()
+	if tarErr := <-extracted; tarErr != nil {
+		return fmt.Errorf("could not extract '%s' to '%s': %v", src, dest, tarErr)
+	}
+	if err != nil {
+		return fmt.Errorf("could not copy '%s' from pod to '%s': %v", src, dest, err)
+	}
+	if code != 0 {
+		return fmt.Errorf("could not copy '%s' from pod to '%s': tar exited with code %d: %s", src, dest, code, tail(errOut.String(), stderrTailLines))
+	}
+	return nil
+}
+
+// writeTar writes the archive of the directory src to w
+func writeTar(w io.Writer, src string, progress Progress) error {
+	tw := tar.NewWriter(w)
+	counter := &progressWriter{progress: progress}
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(src, path)
+		if err != nil || name == "." {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		counter.w, counter.path = tw, header.Name
+		_, err = io.Copy(counter, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// readTar extracts the archive read from r to the directory dest
+func readTar(r io.Reader, dest string, progress Progress) error {
+	tr := tar.NewReader(r)
+	counter := &progressWriter{progress: progress}
+	// Directories get their mode once their files are written, they may not
+	// be writable
+	var dirs []*tar.Header
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		target, err := extractPath(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		if target == dest {
+			continue
+		}
+		mode := header.FileInfo().Mode()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, header)
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			counter.w, counter.path = f, header.Name
+			_, err = io.Copy(counter, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			if err := setAttributes(target, mode, header); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := checkLink(dest, target, header.Linkname); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported entry '%s' of type '%c'", header.Name, header.Typeflag)
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		target, _ := extractPath(dest, dirs[i].Name)
+		if err := setAttributes(target, dirs[i].FileInfo().Mode(), dirs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractPath returns the path of the entry name of an archive extracted to
+// dest. Names escaping dest and paths through links are refused.
+func extractPath(dest, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("refusing entry '%s': absolute path", name)
+	}
+	target := filepath.Join(dest, name)
+	if !within(dest, target) {
+		return "", fmt.Errorf("refusing entry '%s': outside of '%s'", name, dest)
+	}
+	// The entry and its parents must not be links, a link created by the
+	// archive could point anywhere
+	rel, _ := filepath.Rel(dest, target)
+	path := dest
+	for _, component := range strings.Split(rel, string(filepath.Separator)) {
+		if component == "." {
+			continue
+		}
+		path = filepath.Join(path, component)
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("refusing entry '%s': written through link '%s'", name, path)
+		}
+	}
+	return target, nil
+}
+
+// checkLink refuses the links of the archive pointing outside of dest
+func checkLink(dest, target, linkname string) error {
+	if filepath.IsAbs(linkname) || !within(dest, filepath.Join(filepath.Dir(target), linkname)) {
+		return fmt.Errorf("refusing link '%s' to '%s': outside of '%s'", target, linkname, dest)
+	}
+	return nil
+}
+
+// within tells if path is dir or inside of dir
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func setAttributes(path string, mode os.FileMode, header *tar.Header) error {
+	if err := os.Chmod(path, mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	atime := header.AccessTime
+	if atime.IsZero() {
+		atime = header.ModTime
+	}
+	return os.Chtimes(path, atime, header.ModTime)
+}
+
+// progressWriter reports the bytes written to w through progress
+type progressWriter struct {
+	w        io.Writer
+	path     string
+	copied   int64
+	progress Progress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.copied += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.path, p.copied)
+	}
+	return n, err
+}
@@ -0,0 +1,111 @@
+package pod
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]os.FileMode{
+		"toc.dat":         0600,
+		"3001.dat.gz":     0644,
+		"schema/restore":  0755,
+		"schema/empty.gz": 0640,
+	}
+	for name, mode := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("content of "+name), mode); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("toc.dat", filepath.Join(src, "latest")); err != nil {
+		t.Fatal(err)
+	}
+
+	podExec := newLocalPodExec(t)
+	remote := filepath.Join(t.TempDir(), "backup", "dump")
+	var uploaded int64
+	if err := CopyDirToPod(context.Background(), podExec, src, remote, func(path string, copied int64) { uploaded = copied }); err != nil {
+		t.Fatalf("could not copy to pod: %v", err)
+	}
+	dest := filepath.Join(t.TempDir(), "dump")
+	var downloaded int64
+	if err := CopyDirFromPod(context.Background(), podExec, remote, dest, func(path string, copied int64) { downloaded = copied }); err != nil {
+		t.Fatalf("could not copy from pod: %v", err)
+	}
+
+	var size int64
+	for name, mode := range files {
+		for _, dir := range []string{remote, dest} {
+			path := filepath.Join(dir, name)
+			data, err := os.ReadFile(path)
+			if err != nil || string(data) != "content of "+name {
+				t.Errorf("unexpected content of %s '%s', %v", path, data, err)
+			}
+			if info, err := os.Stat(path); err != nil || info.Mode() != mode {
+				t.Errorf("mode of %s should be %s, got %v", path, mode, info.Mode())
+			}
+		}
+		size += int64(len("content of " + name))
+	}
+	if info, err := os.Stat(filepath.Join(dest, "schema")); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("mode of the directory should be preserved, got %v", info.Mode())
+	}
+	if link, err := os.Readlink(filepath.Join(dest, "latest")); err != nil || link != "toc.dat" {
+		t.Errorf("unexpected link '%s', %v", link, err)
+	}
+	if uploaded != size || downloaded != size {
+		t.Errorf("progress should reach %d bytes, got %d and %d", size, uploaded, downloaded)
+	}
+}
+
+func TestCopyDirFromPodMissing(t *testing.T) {
+	err := CopyDirFromPod(context.Background(), newLocalPodExec(t), filepath.Join(t.TempDir(), "missing"), t.TempDir(), nil)
+	if err == nil || !strings.Contains(err.Error(), "tar exited with code") {
+		t.Errorf("expected tar to fail, got %v", err)
+	}
+}
+
+func TestReadTarRefusesEscapes(t *testing.T) {
+	for name, entries := range map[string][]*tar.Header{
+		"parent":        {{Name: "../evil", Typeflag: tar.TypeReg}},
+		"absolute":      {{Name: "/tmp/evil", Typeflag: tar.TypeReg}},
+		"absolute link": {{Name: "etc", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+		"parent link":   {{Name: "up", Typeflag: tar.TypeSymlink, Linkname: "../.."}},
+		"through link": {
+			{Name: "here", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "here/evil", Typeflag: tar.TypeReg},
+		},
+		"over link": {
+			{Name: "sub/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "sub/up", Typeflag: tar.TypeSymlink, Linkname: ".."},
+			{Name: "escape", Typeflag: tar.TypeSymlink, Linkname: "sub/up/.."},
+			{Name: "escape", Typeflag: tar.TypeReg},
+		},
+	} {
+		archive := &bytes.Buffer{}
+		tw := tar.NewWriter(archive)
+		for _, header := range entries {
+			if err := tw.WriteHeader(header); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tw.Close()
+
+		if err := readTar(archive, t.TempDir(), nil); err == nil || !strings.Contains(err.Error(), "refusing") {
+			t.Errorf("%s: expected the archive to be refused, got %v", name, err)
+		}
+	}
+}
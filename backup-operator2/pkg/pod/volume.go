@@ -0,0 +1,131 @@
+package pod
+
+import (
+	"path"
+	"strings"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Where the loader pod mounts the volume
+	LoaderMountPath = "/data"
+
+	defaultLoaderImage = "debian:stable-slim"
+	// Compressed backups are decompressed in the volume, next to the
+	// compressed file until it is removed
+	defaultCompressedFactor = 5
+	mebibyte                = 1024 * 1024
+)
+
+var minVolumeSize = resource.MustParse("1Gi")
+
+// Decompression commands of the compressed backups, by extension
+var decompressCommands = map[string][]string{
+	".xz": {"xz", "-d", "-f"},
+	".gz": {"gzip", "-d", "-f"},
+}
+
+// LoaderPodName is the name of the pod writing the backup of a claim in its
+// volume
+func LoaderPodName(claimName string) string {
+	return claimName + "-loader"
+}
+
+// CreateLoaderPodSpec returns a pod mounting the claim claimName at
+// LoaderMountPath, idle until the backup is written
+func CreateLoaderPodSpec(spec backupsv1beta1.BackupClaimPVCDestinationSpec, name, namespace string) corev1.Pod {
+	image := spec.LoaderImage
+	if image == "" {
+		image = defaultLoaderImage
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    "loader",
+					Image:   image,
+					Command: []string{"sleep", "infinity"},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "backup", MountPath: LoaderMountPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "backup",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: spec.Name},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+// CreatePVCSpec returns the claim of spec, sized for a backup of backupSize
+// bytes named key when spec has no size
+func CreatePVCSpec(spec backupsv1beta1.BackupClaimPVCDestinationSpec, namespace, key string, backupSize int64) corev1.PersistentVolumeClaim {
+	size := VolumeSize(spec, key, backupSize)
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: spec.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+}
+
+// VolumeSize returns the size of spec, or the size of the backup times the
+// decompression factor, rounded up to the mebibyte and at least 1Gi
+func VolumeSize(spec backupsv1beta1.BackupClaimPVCDestinationSpec, key string, backupSize int64) resource.Quantity {
+	if spec.Size != nil {
+		return *spec.Size
+	}
+	factor := int64(spec.DecompressionFactor)
+	if factor == 0 {
+		factor = 1
+		if IsCompressed(key) {
+			factor = defaultCompressedFactor
+		}
+	}
+	bytes := (backupSize*factor + mebibyte - 1) / mebibyte * mebibyte
+	size := resource.NewQuantity(bytes, resource.BinarySI)
+	if size.Cmp(minVolumeSize) < 0 {
+		return minVolumeSize.DeepCopy()
+	}
+	return *size
+}
+
+// IsCompressed tells if the backup named key is compressed with a tool the
+// loader can decompress
+func IsCompressed(key string) bool {
+	_, ok := decompressCommands[path.Ext(key)]
+	return ok
+}
+
+// DecompressCommand returns the command decompressing the file at path, and
+// the path of the decompressed file. The command is nil when the file is not
+// compressed.
+func DecompressCommand(filepath string) ([]string, string) {
+	ext := path.Ext(filepath)
+	command, ok := decompressCommands[ext]
+	if !ok {
+		return nil, filepath
+	}
+	return append(append([]string{}, command...), filepath), strings.TrimSuffix(filepath, ext)
+}
@@ -0,0 +1,37 @@
+package pod
+
+import (
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestVolumeSize(t *testing.T) {
+	size := resource.MustParse("20Gi")
+	for _, c := range []struct {
+		spec     backupsv1beta1.BackupClaimPVCDestinationSpec
+		key      string
+		size     int64
+		expected string
+	}{
+		{backupsv1beta1.BackupClaimPVCDestinationSpec{}, "app.sql", 10, "1Gi"},
+		{backupsv1beta1.BackupClaimPVCDestinationSpec{}, "app.sql", 3 << 30, "3Gi"},
+		{backupsv1beta1.BackupClaimPVCDestinationSpec{}, "app.sql.xz", 3 << 30, "15Gi"},
+		{backupsv1beta1.BackupClaimPVCDestinationSpec{DecompressionFactor: 2}, "app.sql.gz", 3<<30 + 1, "6145Mi"},
+		{backupsv1beta1.BackupClaimPVCDestinationSpec{Size: &size}, "app.sql.xz", 3 << 30, "20Gi"},
+	} {
+		if actual := VolumeSize(c.spec, c.key, c.size); actual.String() != c.expected {
+			t.Errorf("volume of %s of %d bytes should be %s, got %s", c.key, c.size, c.expected, actual.String())
+		}
+	}
+}
+
+func TestDecompressCommand(t *testing.T) {
+	if command, path := DecompressCommand("/data/app.sql.xz"); len(command) != 4 || command[0] != "xz" || path != "/data/app.sql" {
+		t.Errorf("unexpected command %v for %s", command, path)
+	}
+	if command, path := DecompressCommand("/data/app.sql"); command != nil || path != "/data/app.sql" {
+		t.Errorf("uncompressed backups need no command, got %v for %s", command, path)
+	}
+}
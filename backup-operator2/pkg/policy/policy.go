@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeniedError is returned by Check when the policies do not allow a claim.
+// Other errors of Check do not tell whether the claim is allowed.
+type DeniedError struct {
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return e.Reason
+}
+
+// Check returns a *DeniedError if the claim is not allowed by the
+// BackupSourcePolicies of the cluster. Every claim is allowed when no policy
+// exists.
+func Check(ctx context.Context, c client.Reader, backupClaim *backupsv1beta1.BackupClaim) error {
+	var policies backupsv1beta1.BackupSourcePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return fmt.Errorf("could not list backup source policies: %v", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: backupClaim.Namespace}, &namespace); err != nil {
+		return fmt.Errorf("could not get namespace '%s': %v", backupClaim.Namespace, err)
+	}
+
+	applies := false
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		ok, err := AppliesTo(policy, &namespace)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		applies = true
+		if Allows(policy, backupClaim) {
+			return nil
+		}
+	}
+	if !applies {
+		return &DeniedError{Reason: fmt.Sprintf("no backup source policy applies to namespace '%s'", backupClaim.Namespace)}
+	}
+	return &DeniedError{Reason: fmt.Sprintf("no backup source policy allows namespace '%s' to claim '%s'", backupClaim.Namespace, describe(backupClaim))}
+}
+
+// AppliesTo tells if the policy applies to claims of the namespace
+func AppliesTo(policy *backupsv1beta1.BackupSourcePolicy, namespace *corev1.Namespace) (bool, error) {
+	for _, name := range policy.Spec.Namespaces {
+		if name == namespace.Name {
+			return true, nil
+		}
+	}
+	if policy.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector in backup source policy '%s': %v", policy.Name, err)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// Allows tells if the policy allows both the source and the destination of the
+// claim
+func Allows(policy *backupsv1beta1.BackupSourcePolicy, backupClaim *backupsv1beta1.BackupClaim) bool {
+	return allowsSource(policy, backupClaim) && allowsDestination(policy, backupClaim)
+}
+
+func allowsSource(policy *backupsv1beta1.BackupSourcePolicy, backupClaim *backupsv1beta1.BackupClaim) bool {
+	s3 := backupClaim.Spec.Source.S3
+	if s3.BucketName == "" {
+		return true
+	}
+	for _, bucket := range policy.Spec.S3 {
+		if bucket.BucketName != s3.BucketName {
+			continue
+		}
+		if len(bucket.Prefixes) == 0 {
+			return true
+		}
+		for _, prefix := range bucket.Prefixes {
+			if strings.HasPrefix(s3.Key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func allowsDestination(policy *backupsv1beta1.BackupSourcePolicy, backupClaim *backupsv1beta1.BackupClaim) bool {
+	destinations := policy.Spec.Destinations
+	if destinations == nil {
+		return true
+	}
+	if backupClaim.Spec.Destination.Pod.NamePrefix != "" && !destinations.Pod {
+		return false
+	}
+	if backupClaim.Spec.Destination.PVC.Name != "" && !destinations.PVC {
+		return false
+	}
+	if namespace := backupClaim.Spec.Destination.ExistingPod.Namespace; namespace != "" {
+		for _, allowed := range destinations.ExistingPodNamespaces {
+			if allowed == namespace {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func describe(backupClaim *backupsv1beta1.BackupClaim) string {
+	var destination string
+	switch {
+	case backupClaim.Spec.Destination.Pod.NamePrefix != "":
+		destination = "new pod"
+	case backupClaim.Spec.Destination.ExistingPod.Namespace != "":
+		destination = fmt.Sprintf("existing pod in namespace '%s'", backupClaim.Spec.Destination.ExistingPod.Namespace)
+	case backupClaim.Spec.Destination.PVC.Name != "":
+		destination = fmt.Sprintf("volume '%s'", backupClaim.Spec.Destination.PVC.Name)
+	}
+	return fmt.Sprintf("s3://%s/%s to %s", backupClaim.Spec.Source.S3.BucketName, backupClaim.Spec.Source.S3.Key, destination)
+}
@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClaim(namespace, bucket, key string) *backupsv1beta1.BackupClaim {
+	return &backupsv1beta1.BackupClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: namespace},
+		Spec: backupsv1beta1.BackupClaimSpec{
+			Source: backupsv1beta1.BackupClaimSourceSpec{
+				S3: backupsv1beta1.BackupClaimS3SourceSpec{BucketName: bucket, Key: key},
+			},
+			Destination: backupsv1beta1.BackupClaimDestinationSpec{
+				Pod: backupsv1beta1.BackupClaimNewPodDestinationSpec{NamePrefix: "test"},
+			},
+		},
+	}
+}
+
+func newClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = backupsv1beta1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestCheckWithoutPolicies(t *testing.T) {
+	c := newClient()
+	if err := Check(context.TODO(), c, newClaim("dev", "prod-dumps", "db.sql.xz")); err != nil {
+		t.Fatalf("claims should be allowed when no policy exists: %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := newClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"team": "crm"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		&backupsv1beta1.BackupSourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "crm"},
+			Spec: backupsv1beta1.BackupSourcePolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "crm"}},
+				S3: []backupsv1beta1.BackupSourcePolicyS3Spec{
+					{BucketName: "accp-dumps", Prefixes: []string{"crm/"}},
+				},
+				Destinations: &backupsv1beta1.BackupSourcePolicyDestinationsSpec{Pod: true},
+			},
+		},
+	)
+
+	existingPod := newClaim("dev", "accp-dumps", "crm/db.sql.xz")
+	existingPod.Spec.Destination = backupsv1beta1.BackupClaimDestinationSpec{
+		ExistingPod: backupsv1beta1.BackupClaimExistingPodDestinationSpec{Namespace: "prod", Name: "db"},
+	}
+	pvc := newClaim("dev", "accp-dumps", "crm/db.sql.xz")
+	pvc.Spec.Destination = backupsv1beta1.BackupClaimDestinationSpec{
+		PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "db-data"},
+	}
+
+	tests := []struct {
+		name    string
+		claim   *backupsv1beta1.BackupClaim
+		allowed bool
+	}{
+		{"allowed prefix", newClaim("dev", "accp-dumps", "crm/db.sql.xz"), true},
+		{"other prefix", newClaim("dev", "accp-dumps", "billing/db.sql.xz"), false},
+		{"other bucket", newClaim("dev", "prod-dumps", "crm/db.sql.xz"), false},
+		{"namespace without policy", newClaim("other", "accp-dumps", "crm/db.sql.xz"), false},
+		{"existing pod destination not allowed", existingPod, false},
+		{"pvc destination not allowed", pvc, false},
+	}
+
+	for _, test := range tests {
+		err := Check(context.TODO(), c, test.claim)
+		if test.allowed && err != nil {
+			t.Errorf("%s: expected claim to be allowed: %v", test.name, err)
+		}
+		var denied *DeniedError
+		if !test.allowed && !errors.As(err, &denied) {
+			t.Errorf("%s: expected claim to be denied, got %v", test.name, err)
+		}
+	}
+
+	// Denials name the destination of the claim
+	if err := Check(context.TODO(), c, pvc); err == nil || !strings.Contains(err.Error(), "to volume 'db-data'") {
+		t.Errorf("expected the denial to name the volume, got %v", err)
+	}
+
+	// Failing to check the policies does not deny the claim
+	var denied *DeniedError
+	if err := Check(context.TODO(), c, newClaim("missing", "accp-dumps", "crm/db.sql.xz")); err == nil || errors.As(err, &denied) {
+		t.Errorf("expected an error other than a denial for a missing namespace, got %v", err)
+	}
+}
+
+func TestCheckPVCDestination(t *testing.T) {
+	c := newClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}},
+		&backupsv1beta1.BackupSourcePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "volumes"},
+			Spec: backupsv1beta1.BackupSourcePolicySpec{
+				Namespaces:   []string{"dev"},
+				S3:           []backupsv1beta1.BackupSourcePolicyS3Spec{{BucketName: "accp-dumps"}},
+				Destinations: &backupsv1beta1.BackupSourcePolicyDestinationsSpec{PVC: true},
+			},
+		},
+	)
+
+	pvc := newClaim("dev", "accp-dumps", "crm/db.sql.xz")
+	pvc.Spec.Destination = backupsv1beta1.BackupClaimDestinationSpec{
+		PVC: backupsv1beta1.BackupClaimPVCDestinationSpec{Name: "db-data"},
+	}
+	if err := Check(context.TODO(), c, pvc); err != nil {
+		t.Errorf("expected the pvc destination to be allowed: %v", err)
+	}
+	var denied *DeniedError
+	if err := Check(context.TODO(), c, newClaim("dev", "accp-dumps", "crm/db.sql.xz")); !errors.As(err, &denied) {
+		t.Errorf("expected the pod destination to be denied, got %v", err)
+	}
+}
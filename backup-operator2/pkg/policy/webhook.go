@@ -0,0 +1,40 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	backupsv1beta1 "github.com/nvanheuverzwijn/backup-operator/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookPath is the path the BackupClaim validating webhook is served on
+const WebhookPath = "/validate-backups-nvanheuverzwijn-io-v1beta1-backupclaim"
+
+//+kubebuilder:webhook:path=/validate-backups-nvanheuverzwijn-io-v1beta1-backupclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=backups.nvanheuverzwijn.io,resources=backupclaims,verbs=create;update,versions=v1beta1,name=vbackupclaim.kb.io,admissionReviewVersions=v1
+
+// BackupClaimValidator rejects BackupClaims which are not allowed by the
+// BackupSourcePolicies of the cluster
+type BackupClaimValidator struct {
+	Client  client.Reader
+	Decoder admission.Decoder
+}
+
+func (v *BackupClaimValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var backupClaim backupsv1beta1.BackupClaim
+	if err := v.Decoder.Decode(req, &backupClaim); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// The namespace is not always set on the object at creation time
+	backupClaim.Namespace = req.Namespace
+
+	var denied *DeniedError
+	if err := Check(ctx, v.Client, &backupClaim); errors.As(err, &denied) {
+		return admission.Denied(denied.Error())
+	} else if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,118 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// Refresh assumed credentials this long before they actually expire so an
+	// ongoing download never runs with stale credentials
+	credentialsExpiryWindow = 5 * time.Minute
+	// AWS limits role session names to 64 characters
+	maxRoleSessionNameLength = 64
+	// Drop the credentials of claims which stopped reading from S3, the
+	// cache would otherwise keep one entry per claim ever reconciled
+	credentialsIdleTimeout = time.Hour
+)
+
+var invalidRoleSessionNameChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// NewSession creates the operator's base AWS session. When webIdentityRoleARN
+// and webIdentityTokenFile are set, the base credentials are obtained by
+// exchanging the projected service account token (IRSA) for that role.
+// Otherwise the default credential chain is used.
+func NewSession(webIdentityRoleARN, webIdentityTokenFile string) (*session.Session, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	if webIdentityRoleARN == "" || webIdentityTokenFile == "" {
+		return sess, nil
+	}
+	sess.Config.Credentials = stscreds.NewWebIdentityCredentials(sess, webIdentityRoleARN, "backup-operator", webIdentityTokenFile)
+	return sess, nil
+}
+
+// AssumeRoleCache hands out S3 clients which assume an IAM role on top of the
+// base session credentials. Credentials are cached per role, external id and
+// session name, and refreshed before they expire. Credentials left unused for
+// credentialsIdleTimeout are dropped.
+type AssumeRoleCache struct {
+	Session *session.Session
+	mu      sync.Mutex
+	cache   map[string]*cachedCredentials
+	now     func() time.Time
+}
+
+// cachedCredentials are credentials of the cache and when they were last
+// handed out
+type cachedCredentials struct {
+	creds    *credentials.Credentials
+	lastUsed time.Time
+}
+
+func NewAssumeRoleCache(sess *session.Session) *AssumeRoleCache {
+	return &AssumeRoleCache{
+		Session: sess,
+		cache:   make(map[string]*cachedCredentials),
+		now:     time.Now,
+	}
+}
+
+// Credentials returns the cached credentials for the given role, creating
+// them if needed
+func (c *AssumeRoleCache) Credentials(roleARN, externalID, sessionName string) *credentials.Credentials {
+	key := fmt.Sprintf("%s|%s|%s", roleARN, externalID, sessionName)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, cached := range c.cache {
+		if now.Sub(cached.lastUsed) > credentialsIdleTimeout {
+			delete(c.cache, k)
+		}
+	}
+	if cached, ok := c.cache[key]; ok {
+		cached.lastUsed = now
+		return cached.creds
+	}
+	creds := stscreds.NewCredentials(c.Session, roleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = sessionName
+		p.ExpiryWindow = credentialsExpiryWindow
+		if externalID != "" {
+			p.ExternalID = aws.String(externalID)
+		}
+	})
+	c.cache[key] = &cachedCredentials{creds: creds, lastUsed: now}
+	return creds
+}
+
+// S3Client returns an S3 client using the base session, or an assumed role if
+// roleARN is not empty
+func (c *AssumeRoleCache) S3Client(roleARN, externalID, sessionName string) *s3.S3 {
+	if roleARN == "" {
+		return s3.New(c.Session)
+	}
+	return s3.New(c.Session, &aws.Config{
+		Credentials: c.Credentials(roleARN, externalID, sessionName),
+	})
+}
+
+// RoleSessionName builds a valid role session name identifying a claim, so
+// CloudTrail in the bucket account shows which claim read the object
+func RoleSessionName(namespace, name string) string {
+	sessionName := invalidRoleSessionNameChars.ReplaceAllString(fmt.Sprintf("%s.%s", namespace, name), "-")
+	if len(sessionName) > maxRoleSessionNameLength {
+		sessionName = sessionName[:maxRoleSessionNameLength]
+	}
+	return sessionName
+}
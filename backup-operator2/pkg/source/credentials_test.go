@@ -0,0 +1,61 @@
+package source
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+func TestRoleSessionName(t *testing.T) {
+	name := RoleSessionName("team:a", "my claim")
+	if name != "team-a.my-claim" {
+		t.Fatalf("unexpected session name '%s'", name)
+	}
+	name = RoleSessionName("default", strings.Repeat("a", 100))
+	if len(name) != maxRoleSessionNameLength {
+		t.Fatalf("session name should be truncated to %d characters, got %d", maxRoleSessionNameLength, len(name))
+	}
+}
+
+func TestAssumeRoleCacheReusesCredentials(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("us-east-1")})
+	if err != nil {
+		t.Fatalf("could not initialize aws session: %v", err)
+	}
+	cache := NewAssumeRoleCache(sess)
+	first := cache.Credentials("arn:aws:iam::123456789012:role/reader", "ext", "default.claim")
+	second := cache.Credentials("arn:aws:iam::123456789012:role/reader", "ext", "default.claim")
+	if first != second {
+		t.Fatalf("credentials for the same role should be cached")
+	}
+	other := cache.Credentials("arn:aws:iam::123456789012:role/reader", "other", "default.claim")
+	if first == other {
+		t.Fatalf("credentials for a different external id should not be shared")
+	}
+}
+
+func TestAssumeRoleCacheEvictsIdleCredentials(t *testing.T) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String("us-east-1")})
+	if err != nil {
+		t.Fatalf("could not initialize aws session: %v", err)
+	}
+	now := time.Now()
+	cache := NewAssumeRoleCache(sess)
+	cache.now = func() time.Time { return now }
+	const role = "arn:aws:iam::123456789012:role/reader"
+	active := cache.Credentials(role, "", "default.active")
+	cache.Credentials(role, "", "default.deleted")
+
+	now = now.Add(credentialsIdleTimeout)
+	if cache.Credentials(role, "", "default.active") != active {
+		t.Fatalf("credentials in use should be kept")
+	}
+	now = now.Add(time.Minute)
+	cache.Credentials(role, "", "default.active")
+	if len(cache.cache) != 1 {
+		t.Fatalf("idle credentials should be dropped, got %d cached", len(cache.cache))
+	}
+}
@@ -0,0 +1,249 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	DecryptionAge     = "age"
+	DecryptionGPG     = "gpg"
+	DecryptionOpenSSL = "openssl"
+
+	// Defaults of `openssl enc -aes-256-cbc -pbkdf2`
+	opensslMagic      = "Salted__"
+	opensslSaltSize   = 8
+	opensslIterations = 10000
+)
+
+// Extensions added by the encryption tools, removed from the name of the
+// delivered file once decrypted
+var encryptedExtensions = map[string]bool{".age": true, ".gpg": true, ".pgp": true, ".asc": true, ".enc": true}
+
+// Decryption describes how to decrypt a client side encrypted backup
+type Decryption struct {
+	// One of DecryptionAge, DecryptionGPG or DecryptionOpenSSL
+	Type string
+	// age identities or gpg private key
+	Key []byte
+	// age or gpg passphrase, gpg private key passphrase or openssl password
+	Passphrase []byte
+}
+
+// NewDecryptReader returns a reader decrypting r as it is read
+func NewDecryptReader(r io.Reader, d Decryption) (io.Reader, error) {
+	switch d.Type {
+	case DecryptionAge:
+		return newAgeReader(r, d.Key, d.Passphrase)
+	case DecryptionGPG:
+		return newGPGReader(r, d.Key, d.Passphrase)
+	case DecryptionOpenSSL:
+		return newOpenSSLReader(r, d.Passphrase)
+	default:
+		return nil, fmt.Errorf("unsupported decryption type '%s'", d.Type)
+	}
+}
+
+// TrimEncryptedExtension removes the extension added by the encryption tool
+// from path, if any
+func TrimEncryptedExtension(path string) string {
+	if encryptedExtensions[filepath.Ext(path)] {
+		return strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	return path
+}
+
+// newAgeReader returns a reader decrypting the age file read from r, armored
+// or not. identities holds an age identity file, passphrase is used for files
+// encrypted with a passphrase.
+func newAgeReader(r io.Reader, identities, passphrase []byte) (io.Reader, error) {
+	var ids []age.Identity
+	if len(identities) != 0 {
+		parsed, err := age.ParseIdentities(bytes.NewReader(identities))
+		if err != nil {
+			return nil, fmt.Errorf("could not read age identities: %v", err)
+		}
+		ids = append(ids, parsed...)
+	}
+	if len(passphrase) != 0 {
+		scrypt, err := age.NewScryptIdentity(string(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("could not use age passphrase: %v", err)
+		}
+		ids = append(ids, scrypt)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("age decryption requires identities or a passphrase")
+	}
+
+	br := bufio.NewReader(r)
+	if peek, _ := br.Peek(len(agearmor.Header)); string(peek) == agearmor.Header {
+		r = agearmor.NewReader(br)
+	} else {
+		r = br
+	}
+	dr, err := age.Decrypt(r, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt age file: %v", err)
+	}
+	return dr, nil
+}
+
+// newGPGReader returns a reader decrypting the gpg message read from r, with
+// the private key or the passphrase. It uses the maintained fork of the
+// deprecated golang.org/x/crypto/openpgp.
+func newGPGReader(r io.Reader, key, passphrase []byte) (io.Reader, error) {
+	var keyring openpgp.EntityList
+	if len(key) != 0 {
+		var err error
+		if bytes.Contains(key, []byte("-----BEGIN PGP")) {
+			keyring, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+		} else {
+			keyring, err = openpgp.ReadKeyRing(bytes.NewReader(key))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read gpg key: %v", err)
+		}
+		if len(passphrase) != 0 {
+			for _, entity := range keyring {
+				if entity.PrivateKey != nil && entity.PrivateKey.Encrypted {
+					if err := entity.PrivateKey.Decrypt(passphrase); err != nil {
+						return nil, fmt.Errorf("could not decrypt gpg private key: %v", err)
+					}
+				}
+				for _, subkey := range entity.Subkeys {
+					if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+						if err := subkey.PrivateKey.Decrypt(passphrase); err != nil {
+							return nil, fmt.Errorf("could not decrypt gpg private subkey: %v", err)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Encrypted backups may be armored
+	br := bufio.NewReader(r)
+	if peek, _ := br.Peek(len("-----BEGIN PGP")); string(peek) == "-----BEGIN PGP" {
+		block, err := armor.Decode(br)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode armored gpg message: %v", err)
+		}
+		r = block.Body
+	} else {
+		r = br
+	}
+
+	prompted := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		// Called again when the passphrase is wrong
+		if !symmetric || prompted || len(passphrase) == 0 {
+			return nil, errors.New("no usable gpg key or passphrase")
+		}
+		prompted = true
+		return passphrase, nil
+	}
+	md, err := openpgp.ReadMessage(r, keyring, prompt, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt gpg message: %v", err)
+	}
+	return md.UnverifiedBody, nil
+}
+
+// opensslReader decrypts files produced by `openssl enc -aes-256-cbc -pbkdf2`
+type opensslReader struct {
+	src   io.Reader
+	mode  cipher.BlockMode
+	plain []byte
+	// Last decrypted block, held back until we know if it holds the padding
+	last []byte
+	eof  bool
+}
+
+func newOpenSSLReader(r io.Reader, passphrase []byte) (io.Reader, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("openssl decryption requires a passphrase")
+	}
+	header := make([]byte, len(opensslMagic)+opensslSaltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("could not read openssl header: %v", err)
+	}
+	if string(header[:len(opensslMagic)]) != opensslMagic {
+		return nil, errors.New("not an openssl salted file")
+	}
+	salt := header[len(opensslMagic):]
+	keyiv := pbkdf2.Key(passphrase, salt, opensslIterations, 32+aes.BlockSize, sha256.New)
+	block, err := aes.NewCipher(keyiv[:32])
+	if err != nil {
+		return nil, err
+	}
+	return &opensslReader{
+		src:  r,
+		mode: cipher.NewCBCDecrypter(block, keyiv[32:]),
+	}, nil
+}
+
+func (o *opensslReader) Read(p []byte) (int, error) {
+	for len(o.plain) == 0 {
+		if o.eof {
+			return 0, io.EOF
+		}
+		if err := o.fill(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, o.plain)
+	o.plain = o.plain[n:]
+	return n, nil
+}
+
+func (o *opensslReader) fill() error {
+	buf := make([]byte, 32*1024)
+	n, err := io.ReadFull(o.src, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	if n%aes.BlockSize != 0 {
+		return errors.New("openssl payload is not a multiple of the block size")
+	}
+	buf = buf[:n]
+	o.mode.CryptBlocks(buf, buf)
+
+	data := append(o.last, buf...)
+	if err == nil {
+		// Hold back the last block, it may be the padding
+		o.plain = data[:len(data)-aes.BlockSize]
+		o.last = append([]byte{}, data[len(data)-aes.BlockSize:]...)
+		return nil
+	}
+
+	o.eof = true
+	if len(data) == 0 {
+		return errors.New("openssl payload is empty")
+	}
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > aes.BlockSize || padding > len(data) {
+		return errors.New("bad openssl padding, wrong passphrase?")
+	}
+	for _, b := range data[len(data)-padding:] {
+		if int(b) != padding {
+			return errors.New("bad openssl padding, wrong passphrase?")
+		}
+	}
+	o.plain = data[:len(data)-padding]
+	return nil
+}
@@ -0,0 +1,93 @@
+package source
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Fixtures in testdata were produced by age, gpg and `openssl enc -aes-256-cbc -pbkdf2`
+// from testdata/backup.sql. Every passphrase is "hunter2".
+func TestNewDecryptReader(t *testing.T) {
+	plain := readTestdata(t, "backup.sql")
+	identity := readTestdata(t, "age-identity.txt")
+	gpgKey := readTestdata(t, "gpg-private-key.asc")
+	passphrase := []byte("hunter2")
+
+	tests := []struct {
+		file       string
+		decryption Decryption
+	}{
+		{"backup.sql.age", Decryption{Type: DecryptionAge, Key: identity}},
+		{"backup-passphrase.sql.age", Decryption{Type: DecryptionAge, Passphrase: passphrase}},
+		{"backup-armored.sql.age", Decryption{Type: DecryptionAge, Key: identity}},
+		{"backup.sql.gpg", Decryption{Type: DecryptionGPG, Key: gpgKey, Passphrase: passphrase}},
+		{"backup-symmetric.sql.gpg", Decryption{Type: DecryptionGPG, Passphrase: passphrase}},
+		{"backup.sql.enc", Decryption{Type: DecryptionOpenSSL, Passphrase: passphrase}},
+	}
+	for _, test := range tests {
+		f, err := os.Open(filepath.Join("testdata", test.file))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		reader, err := NewDecryptReader(f, test.decryption)
+		if err != nil {
+			f.Close()
+			t.Errorf("%s: could not create decrypt reader: %v", test.file, err)
+			continue
+		}
+		out, err := ioutil.ReadAll(reader)
+		f.Close()
+		if err != nil {
+			t.Errorf("%s: could not decrypt: %v", test.file, err)
+			continue
+		}
+		if !bytes.Equal(out, plain) {
+			t.Errorf("%s: decrypted content does not match, got %d bytes, expected %d", test.file, len(out), len(plain))
+		}
+	}
+}
+
+func TestNewDecryptReaderWrongKey(t *testing.T) {
+	tests := []struct {
+		file       string
+		decryption Decryption
+	}{
+		{"backup-passphrase.sql.age", Decryption{Type: DecryptionAge, Passphrase: []byte("wrong")}},
+		{"backup.sql.enc", Decryption{Type: DecryptionOpenSSL, Passphrase: []byte("wrong")}},
+		{"backup-symmetric.sql.gpg", Decryption{Type: DecryptionGPG, Passphrase: []byte("wrong")}},
+	}
+	for _, test := range tests {
+		f, err := os.Open(filepath.Join("testdata", test.file))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		reader, err := NewDecryptReader(f, test.decryption)
+		if err == nil {
+			_, err = ioutil.ReadAll(reader)
+		}
+		f.Close()
+		if err == nil {
+			t.Errorf("%s: expected an error with a wrong passphrase", test.file)
+		}
+	}
+}
+
+func TestTrimEncryptedExtension(t *testing.T) {
+	if got := TrimEncryptedExtension("2021/12/01/db.sql.xz.age"); got != "2021/12/01/db.sql.xz" {
+		t.Errorf("unexpected path '%s'", got)
+	}
+	if got := TrimEncryptedExtension("2021/12/01/db.sql.xz"); got != "2021/12/01/db.sql.xz" {
+		t.Errorf("unexpected path '%s'", got)
+	}
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	b, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return b
+}
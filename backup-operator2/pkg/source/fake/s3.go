@@ -0,0 +1,285 @@
+// Package fake provides an in-memory S3 to test the S3 source without AWS
+package fake
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// Version of an object stored in the fake S3
+type Version struct {
+	ID           string
+	Data         []byte
+	StorageClass string
+	DeleteMarker bool
+	// Value of the x-amz-restore header of archived objects
+	Restore string
+	// Key the object is encrypted with, for SSE-C
+	SSECustomerKey []byte
+}
+
+// S3 implements the calls of s3iface.S3API used by the S3 source. Calling any
+// other method panics.
+type S3 struct {
+	s3iface.S3API
+
+	mu       sync.Mutex
+	objects  map[string][]*Version
+	counter  int
+	errors   map[string]error
+	requests []string
+}
+
+func NewS3() *S3 {
+	return &S3{
+		objects: make(map[string][]*Version),
+		errors:  make(map[string]error),
+	}
+}
+
+func objectKey(bucket, key string) string {
+	return bucket + "/" + key
+}
+
+// AddObject stores a new version of the object and returns its version id
+func (f *S3) AddObject(bucket, key string, data []byte) string {
+	return f.AddVersion(bucket, key, Version{Data: data})
+}
+
+// AddVersion stores a new version of the object and returns its version id
+func (f *S3) AddVersion(bucket, key string, version Version) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.counter++
+	if version.ID == "" {
+		version.ID = fmt.Sprintf("v%d", f.counter)
+	}
+	if version.StorageClass == "" && !version.DeleteMarker {
+		version.StorageClass = s3.StorageClassStandard
+	}
+	k := objectKey(bucket, key)
+	f.objects[k] = append([]*Version{&version}, f.objects[k]...)
+	return version.ID
+}
+
+// AddDeleteMarker hides the object behind a delete marker
+func (f *S3) AddDeleteMarker(bucket, key string) string {
+	return f.AddVersion(bucket, key, Version{DeleteMarker: true})
+}
+
+// CompleteRestore finishes the Glacier restore of every version of the object
+func (f *S3) CompleteRestore(bucket, key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, version := range f.objects[objectKey(bucket, key)] {
+		if version.Restore != "" {
+			version.Restore = `ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`
+		}
+	}
+}
+
+// FailOn makes every call of the operation, like "GetObject", return err
+func (f *S3) FailOn(operation string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[operation] = err
+}
+
+// Requests returns the operations called so far
+func (f *S3) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.requests...)
+}
+
+func (f *S3) call(operation string) error {
+	f.requests = append(f.requests, operation)
+	return f.errors[operation]
+}
+
+func requestFailure(code string, status int) error {
+	return awserr.NewRequestFailure(awserr.New(code, code, nil), status, "fake")
+}
+
+// find returns the requested version, or the latest one
+func (f *S3) find(bucket, key, versionID *string) (*Version, error) {
+	versions := f.objects[objectKey(aws.StringValue(bucket), aws.StringValue(key))]
+	for _, version := range versions {
+		if versionID == nil || version.ID == aws.StringValue(versionID) {
+			if version.DeleteMarker {
+				return nil, requestFailure(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+			}
+			return version, nil
+		}
+		if versionID == nil {
+			break
+		}
+	}
+	return nil, requestFailure(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+}
+
+func checkSSECustomerKey(version *Version, key *string) error {
+	if len(version.SSECustomerKey) == 0 {
+		return nil
+	}
+	if aws.StringValue(key) != string(version.SSECustomerKey) {
+		return requestFailure("InvalidRequest", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func (f *S3) ListObjectVersionsWithContext(ctx aws.Context, in *s3.ListObjectVersionsInput, opts ...request.Option) (*s3.ListObjectVersionsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("ListObjectVersions"); err != nil {
+		return nil, err
+	}
+	out := &s3.ListObjectVersionsOutput{}
+	prefix := objectKey(aws.StringValue(in.Bucket), aws.StringValue(in.Prefix))
+	for k, versions := range f.objects {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		key := strings.TrimPrefix(k, aws.StringValue(in.Bucket)+"/")
+		for i, version := range versions {
+			if version.DeleteMarker {
+				out.DeleteMarkers = append(out.DeleteMarkers, &s3.DeleteMarkerEntry{
+					Key:       aws.String(key),
+					VersionId: aws.String(version.ID),
+					IsLatest:  aws.Bool(i == 0),
+				})
+				continue
+			}
+			out.Versions = append(out.Versions, &s3.ObjectVersion{
+				Key:          aws.String(key),
+				VersionId:    aws.String(version.ID),
+				IsLatest:     aws.Bool(i == 0),
+				Size:         aws.Int64(int64(len(version.Data))),
+				StorageClass: aws.String(version.StorageClass),
+				ETag:         aws.String(fmt.Sprintf("\"%x\"", md5.Sum(version.Data))),
+			})
+		}
+	}
+	return out, nil
+}
+
+func (f *S3) GetObjectWithContext(ctx aws.Context, in *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("GetObject"); err != nil {
+		return nil, err
+	}
+	version, err := f.find(in.Bucket, in.Key, in.VersionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSSECustomerKey(version, in.SSECustomerKey); err != nil {
+		return nil, err
+	}
+	archived := version.StorageClass == s3.StorageClassGlacier || version.StorageClass == s3.StorageClassDeepArchive
+	if archived && !strings.Contains(version.Restore, `ongoing-request="false"`) {
+		return nil, requestFailure(s3.ErrCodeInvalidObjectState, http.StatusForbidden)
+	}
+
+	size := int64(len(version.Data))
+	start, end := int64(0), size-1
+	if in.Range != nil {
+		bounds := strings.SplitN(strings.TrimPrefix(aws.StringValue(in.Range), "bytes="), "-", 2)
+		if len(bounds) != 2 {
+			return nil, requestFailure("InvalidRange", http.StatusRequestedRangeNotSatisfiable)
+		}
+		start, _ = strconv.ParseInt(bounds[0], 10, 64)
+		if bounds[1] != "" {
+			end, _ = strconv.ParseInt(bounds[1], 10, 64)
+		}
+		if start >= size {
+			return nil, requestFailure("InvalidRange", http.StatusRequestedRangeNotSatisfiable)
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	data := version.Data[start : end+1]
+	out := &s3.GetObjectOutput{
+		Body:          ioutil.NopCloser(bytes.NewReader(data)),
+		ContentLength: aws.Int64(int64(len(data))),
+		VersionId:     aws.String(version.ID),
+	}
+	if in.Range != nil {
+		out.ContentRange = aws.String(fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	}
+	return out, nil
+}
+
+func (f *S3) HeadObjectWithContext(ctx aws.Context, in *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("HeadObject"); err != nil {
+		return nil, err
+	}
+	version, err := f.find(in.Bucket, in.Key, in.VersionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSSECustomerKey(version, in.SSECustomerKey); err != nil {
+		return nil, err
+	}
+	out := &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(int64(len(version.Data))),
+		StorageClass:  aws.String(version.StorageClass),
+		VersionId:     aws.String(version.ID),
+	}
+	if version.Restore != "" {
+		out.Restore = aws.String(version.Restore)
+	}
+	return out, nil
+}
+
+func (f *S3) DeleteObjectWithContext(ctx aws.Context, in *s3.DeleteObjectInput, opts ...request.Option) (*s3.DeleteObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("DeleteObject"); err != nil {
+		return nil, err
+	}
+	k := objectKey(aws.StringValue(in.Bucket), aws.StringValue(in.Key))
+	versions := f.objects[k]
+	for i, version := range versions {
+		if version.ID == aws.StringValue(in.VersionId) {
+			f.objects[k] = append(versions[:i:i], versions[i+1:]...)
+			return &s3.DeleteObjectOutput{VersionId: in.VersionId}, nil
+		}
+	}
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (f *S3) RestoreObjectWithContext(ctx aws.Context, in *s3.RestoreObjectInput, opts ...request.Option) (*s3.RestoreObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("RestoreObject"); err != nil {
+		return nil, err
+	}
+	version, err := f.find(in.Bucket, in.Key, in.VersionId)
+	if err != nil {
+		return nil, err
+	}
+	if version.StorageClass != s3.StorageClassGlacier && version.StorageClass != s3.StorageClassDeepArchive {
+		return nil, requestFailure(s3.ErrCodeInvalidObjectState, http.StatusForbidden)
+	}
+	if strings.Contains(version.Restore, `ongoing-request="true"`) {
+		return nil, requestFailure("RestoreAlreadyInProgress", http.StatusConflict)
+	}
+	version.Restore = `ongoing-request="true"`
+	return &s3.RestoreObjectOutput{}, nil
+}
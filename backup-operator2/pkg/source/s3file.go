@@ -1,18 +1,42 @@
 package source
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
+	"strings"
+)
+
+// State of an object regarding Glacier
+type GlacierState string
+
+const (
+	// The object is not archived and can be read
+	GlacierStateNone GlacierState = "None"
+	// The object is archived and no restore was requested
+	GlacierStateArchived GlacierState = "Archived"
+	// The object is being restored
+	GlacierStateRestoring GlacierState = "Restoring"
+	// A temporary copy of the object was restored and can be read
+	GlacierStateRestored GlacierState = "Restored"
 )
 
 type S3File struct {
-	BucketName       string
-	Path             string
-	S3Client         *s3.S3
+	BucketName string
+	Path       string
+	S3Client   s3iface.S3API
+	// Customer provided key of objects encrypted with SSE-C. Objects encrypted
+	// with SSE-S3 or SSE-KMS are decrypted by S3 and need no key.
+	SSECustomerKey   []byte
 	obj              *s3.ListObjectVersionsOutput
 	objLatestVersion *s3.ObjectVersion
 	objByte          []byte
@@ -21,7 +45,7 @@ type S3File struct {
 	downloader       *s3manager.Downloader
 }
 
-func NewS3File(ctx context.Context, bucketname, path string, s3client *s3.S3) (*S3File, error) {
+func NewS3File(ctx context.Context, bucketname, path string, s3client s3iface.S3API) (*S3File, error) {
 	s3file := &S3File{
 		BucketName: bucketname,
 		Path:       path,
@@ -37,10 +61,24 @@ func NewS3File(ctx context.Context, bucketname, path string, s3client *s3.S3) (*
 	return s3file, nil
 }
 
+// ParseSSECustomerKey accepts a raw or base64 encoded 256 bits key
+func ParseSSECustomerKey(key []byte) ([]byte, error) {
+	if len(key) == 32 {
+		return key, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(key)))
+	if err != nil || len(decoded) != 32 {
+		return nil, fmt.Errorf("SSE-C key must be 256 bits, raw or base64 encoded")
+	}
+	return decoded, nil
+}
+
 func (s *S3File) GetObjectByte() []byte {
 	return s.objByte
 }
 
+// GetObject lists the versions and delete markers of the object. The version
+// read is the latest one, or the most recent one hidden by a delete marker.
 func (s *S3File) GetObject() (*s3.ListObjectVersionsOutput, error) {
 	if s.obj != nil {
 		return s.obj, nil
@@ -50,31 +88,83 @@ func (s *S3File) GetObject() (*s3.ListObjectVersionsOutput, error) {
 		Bucket: aws.String(s.BucketName),
 		Prefix: aws.String(s.Path),
 	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get object '%s': %v", s.URL(), err)
+	}
+
+	// The prefix also matches other keys starting with the path
+	filtered := &s3.ListObjectVersionsOutput{}
 	for _, version := range obj.Versions {
-		if *version.IsLatest {
-			s.objLatestVersion = version
+		if aws.StringValue(version.Key) == s.Path {
+			filtered.Versions = append(filtered.Versions, version)
+		}
+	}
+	for _, marker := range obj.DeleteMarkers {
+		if aws.StringValue(marker.Key) == s.Path {
+			filtered.DeleteMarkers = append(filtered.DeleteMarkers, marker)
 		}
 	}
+	if len(filtered.Versions) == 0 {
+		return nil, fmt.Errorf("could not get object '%s': no version found", s.URL())
+	}
 
-	if err != nil {
-		return nil, fmt.Errorf("could not get object '%s': %v", s.URL(), err)
+	// Versions are listed from the most recent to the oldest
+	s.objLatestVersion = filtered.Versions[0]
+	for _, version := range filtered.Versions {
+		if aws.BoolValue(version.IsLatest) {
+			s.objLatestVersion = version
+		}
 	}
-	s.obj = obj
+	s.obj = filtered
 
 	return s.obj, nil
 }
 
+// Size of the object in bytes
+func (s *S3File) Size() int64 {
+	return aws.Int64Value(s.objLatestVersion.Size)
+}
+
+// VersionID of the object being read
+func (s *S3File) VersionID() string {
+	return aws.StringValue(s.objLatestVersion.VersionId)
+}
+
+// ETag of the version being read
+func (s *S3File) ETag() string {
+	return aws.StringValue(s.objLatestVersion.ETag)
+}
+
+// SetContext sets the context of the requests made by Read
+func (s *S3File) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
 func (s *S3File) Read(b []byte) (n int, err error) {
-	if s.objByteReadIndex >= *s.objLatestVersion.Size {
+	if s.objByteReadIndex >= s.Size() {
 		s.objByteReadIndex = 0
 		return 0, io.EOF
 	}
 	in := aws.NewWriteAtBuffer(b)
-	bytesRead, err := s.downloader.DownloadWithContext(s.ctx, in, &s3.GetObjectInput{
-		Bucket: aws.String(s.BucketName),
-		Key:    aws.String(s.Path),
-		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", s.objByteReadIndex, int(s.objByteReadIndex)+len(b)-1)),
-	})
+	byteRange := fmt.Sprintf("bytes=%d-%d", s.objByteReadIndex, int(s.objByteReadIndex)+len(b)-1)
+	input := &s3.GetObjectInput{
+		Bucket:    aws.String(s.BucketName),
+		Key:       aws.String(s.Path),
+		VersionId: s.objLatestVersion.VersionId,
+		Range:     aws.String(byteRange),
+	}
+	if len(s.SSECustomerKey) != 0 {
+		input.SSECustomerAlgorithm = aws.String(s3.ServerSideEncryptionAes256)
+		input.SSECustomerKey = aws.String(string(s.SSECustomerKey))
+	}
+	ctx, span := tracing.Tracer().Start(s.ctx, "S3File.Read", trace.WithAttributes(
+		attribute.String("s3.bucket", s.BucketName),
+		attribute.String("s3.key", s.Path),
+		attribute.String("s3.range", byteRange),
+	))
+	bytesRead, err := s.downloader.DownloadWithContext(ctx, in, input)
+	span.SetAttributes(attribute.Int64("s3.bytes", bytesRead))
+	tracing.End(span, err)
 	s.objByteReadIndex += bytesRead
 	if err != nil {
 		s.objByteReadIndex = 0
@@ -106,14 +196,19 @@ func (s *S3File) RestoreFromGlacier() error {
 	if err != nil {
 		return err
 	}
+	// Expedited retrievals are not available for Deep Archive
+	tier := s3.TierExpedited
+	if aws.StringValue(s.objLatestVersion.StorageClass) == s3.StorageClassDeepArchive {
+		tier = s3.TierStandard
+	}
 	_, err = s.S3Client.RestoreObjectWithContext(s.ctx, &s3.RestoreObjectInput{
 		Bucket:    aws.String(s.BucketName),
-		Key:       s.obj.Versions[0].Key,
-		VersionId: s.obj.Versions[0].VersionId,
+		Key:       s.objLatestVersion.Key,
+		VersionId: s.objLatestVersion.VersionId,
 		RestoreRequest: &s3.RestoreRequest{
 			Days: aws.Int64(1),
 			GlacierJobParameters: &s3.GlacierJobParameters{
-				Tier: aws.String(s3.TierExpedited),
+				Tier: aws.String(tier),
 			},
 			Description:      nil,
 			OutputLocation:   nil,
@@ -129,17 +224,44 @@ func (s *S3File) RestoreFromGlacier() error {
 }
 
 func (s *S3File) GetGlacierStatus() (*s3.HeadObjectOutput, error) {
-	res, err := s.S3Client.HeadObjectWithContext(s.ctx, &s3.HeadObjectInput{
+	input := &s3.HeadObjectInput{
 		Bucket:    aws.String(s.BucketName),
-		Key:       aws.String(s.String()),
-		VersionId: s.obj.Versions[0].VersionId,
-	})
+		Key:       aws.String(s.Path),
+		VersionId: s.objLatestVersion.VersionId,
+	}
+	if len(s.SSECustomerKey) != 0 {
+		input.SSECustomerAlgorithm = aws.String(s3.ServerSideEncryptionAes256)
+		input.SSECustomerKey = aws.String(string(s.SSECustomerKey))
+	}
+	res, err := s.S3Client.HeadObjectWithContext(s.ctx, input)
 
 	return res, err
 }
 
+// GetGlacierState tells if the object is archived, being restored or restored.
+// The restore status is reported by HeadObject in the x-amz-restore header.
+func (s *S3File) GetGlacierState() (GlacierState, error) {
+	if !s.IsGlacier() {
+		return GlacierStateNone, nil
+	}
+	res, err := s.GetGlacierStatus()
+	if err != nil {
+		return "", fmt.Errorf("could not get glacier status of s3file '%s': %v", s.URL(), err)
+	}
+	restore := aws.StringValue(res.Restore)
+	switch {
+	case strings.Contains(restore, `ongoing-request="true"`):
+		return GlacierStateRestoring, nil
+	case strings.Contains(restore, `ongoing-request="false"`):
+		return GlacierStateRestored, nil
+	default:
+		return GlacierStateArchived, nil
+	}
+}
+
 func (s *S3File) IsGlacier() bool {
-	return *s.obj.Versions[0].StorageClass == s3.StorageClassGlacier
+	storageClass := aws.StringValue(s.objLatestVersion.StorageClass)
+	return storageClass == s3.StorageClassGlacier || storageClass == s3.StorageClassDeepArchive
 }
 
 func (s *S3File) String() string {
@@ -1,40 +1,236 @@
 package source
 
 import (
+	"bytes"
 	"context"
-	"fmt"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"errors"
 	"io"
-	"log"
-	"os"
+	"io/ioutil"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/nvanheuverzwijn/backup-operator/pkg/source/fake"
 )
 
-// To test this function, you have to have proper crm-admin credentials
-// aws-vault exec crm-admin
-// go test
+const testBucket = "db-backup-kt.accp.kronos-crm.com"
+const testKey = "2021/12/01/abex__109.sql.xz"
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
 func TestS3FileDownloadStream(t *testing.T) {
-	sess, err := session.NewSession()
+	s3client := fake.NewS3()
+	data := testData(200*1024 + 17)
+	s3client.AddObject(testBucket, testKey, data)
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
 	if err != nil {
-		log.Fatalf("could not initialize aws session: %v", err)
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	out := &bytes.Buffer{}
+	if _, err = io.Copy(out, s3file); err != nil {
+		t.Fatalf("could not download s3file: %v", err)
+	}
+	if int64(out.Len()) != s3file.Size() {
+		t.Fatalf("size does not match '%d' != '%d'", out.Len(), s3file.Size())
 	}
-	s3file, err := NewS3File(context.TODO(), "db-backup-kt.accp.kronos-crm.com", "2021/12/01/abex__109.sql.xz", s3.New(sess))
+	if !bytes.Equal(out.Bytes(), data) {
+		t.Fatalf("downloaded content does not match")
+	}
+}
+
+func TestS3FileRangedReads(t *testing.T) {
+	s3client := fake.NewS3()
+	data := testData(1000)
+	s3client.AddObject(testBucket, testKey, data)
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
 	if err != nil {
-		log.Fatalf("could not initialize s3file: %v", err)
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	buf := make([]byte, 300)
+	var out []byte
+	for {
+		n, err := s3file.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("could not read s3file: %v", err)
+		}
+		if n > len(buf) {
+			t.Fatalf("read more than the buffer size")
+		}
 	}
-	f, _ := os.CreateTemp("/tmp", "test")
-	defer f.Close()
-	defer os.Remove(f.Name())
-	_, err = io.Copy(f, s3file)
+	if !bytes.Equal(out, data) {
+		t.Fatalf("downloaded content does not match")
+	}
+
+	// The file can be read again once EOF was reached
+	again, err := ioutil.ReadAll(s3file)
+	if err != nil || !bytes.Equal(again, data) {
+		t.Fatalf("could not read s3file a second time: %v", err)
+	}
+}
+
+func TestS3FileVersions(t *testing.T) {
+	s3client := fake.NewS3()
+	s3client.AddObject(testBucket, testKey, []byte("old"))
+	latest := s3client.AddObject(testBucket, testKey, []byte("latest"))
+	// Shares the prefix of the key but is another object
+	s3client.AddObject(testBucket, testKey+".sha256", []byte("checksum"))
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
+	if err != nil {
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	if s3file.VersionID() != latest {
+		t.Fatalf("expected version '%s', got '%s'", latest, s3file.VersionID())
+	}
+	out, err := ioutil.ReadAll(s3file)
+	if err != nil || string(out) != "latest" {
+		t.Fatalf("expected latest version content, got '%s' (%v)", out, err)
+	}
+}
+
+func TestS3FileDeleteMarker(t *testing.T) {
+	s3client := fake.NewS3()
+	version := s3client.AddObject(testBucket, testKey, []byte("deleted"))
+	s3client.AddDeleteMarker(testBucket, testKey)
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
 	if err != nil {
-		fmt.Printf("error: %v", err)
+		t.Fatalf("could not initialize s3file: %v", err)
 	}
-	finfo, err := os.Stat(f.Name())
+	if s3file.VersionID() != version {
+		t.Fatalf("expected version hidden by the delete marker '%s', got '%s'", version, s3file.VersionID())
+	}
+	out, err := ioutil.ReadAll(s3file)
+	if err != nil || string(out) != "deleted" {
+		t.Fatalf("expected content hidden by the delete marker, got '%s' (%v)", out, err)
+	}
+
+	if err := s3file.RemoveDeleteMarker(); err != nil {
+		t.Fatalf("could not remove delete marker: %v", err)
+	}
+	listed, err := s3client.ListObjectVersionsWithContext(context.TODO(), &s3.ListObjectVersionsInput{})
+	if err != nil || len(listed.DeleteMarkers) != 0 {
+		t.Fatalf("delete marker should have been removed")
+	}
+}
+
+func TestS3FileGlacierStates(t *testing.T) {
+	s3client := fake.NewS3()
+	data := testData(100)
+	s3client.AddVersion(testBucket, testKey, fake.Version{Data: data, StorageClass: s3.StorageClassGlacier})
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
+	if err != nil {
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	assertGlacierState(t, s3file, GlacierStateArchived)
+	if _, err := ioutil.ReadAll(s3file); err == nil {
+		t.Fatalf("reading an archived object should fail")
+	}
+
+	if err := s3file.RestoreFromGlacier(); err != nil {
+		t.Fatalf("could not restore s3file: %v", err)
+	}
+	assertGlacierState(t, s3file, GlacierStateRestoring)
+	if err := s3file.RestoreFromGlacier(); err == nil {
+		t.Fatalf("restoring twice should fail")
+	}
+
+	s3client.CompleteRestore(testBucket, testKey)
+	assertGlacierState(t, s3file, GlacierStateRestored)
+	out, err := ioutil.ReadAll(s3file)
+	if err != nil || !bytes.Equal(out, data) {
+		t.Fatalf("could not read restored s3file: %v", err)
+	}
+}
+
+func TestS3FileNotInGlacier(t *testing.T) {
+	s3client := fake.NewS3()
+	s3client.AddObject(testBucket, testKey, []byte("data"))
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
+	if err != nil {
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	assertGlacierState(t, s3file, GlacierStateNone)
+	if err := s3file.RestoreFromGlacier(); err == nil {
+		t.Fatalf("restoring an object not in glacier should fail")
+	}
+}
+
+func TestS3FileSSECustomerKey(t *testing.T) {
+	s3client := fake.NewS3()
+	key := bytes.Repeat([]byte("k"), 32)
+	s3client.AddVersion(testBucket, testKey, fake.Version{Data: []byte("secret"), SSECustomerKey: key})
+
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
+	if err != nil {
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	if _, err := ioutil.ReadAll(s3file); err == nil {
+		t.Fatalf("reading without the SSE-C key should fail")
+	}
+	s3file.SSECustomerKey = key
+	out, err := ioutil.ReadAll(s3file)
+	if err != nil || string(out) != "secret" {
+		t.Fatalf("could not read with the SSE-C key: %v", err)
+	}
+}
+
+func TestS3FileErrors(t *testing.T) {
+	s3client := fake.NewS3()
+	if _, err := NewS3File(context.TODO(), testBucket, testKey, s3client); err == nil {
+		t.Fatalf("expected an error for a missing object")
+	}
+
+	s3client.AddObject(testBucket, testKey, []byte("data"))
+	s3client.FailOn("ListObjectVersions", errors.New("access denied"))
+	if _, err := NewS3File(context.TODO(), testBucket, testKey, s3client); err == nil {
+		t.Fatalf("expected list errors to be returned")
+	}
+
+	s3client.FailOn("ListObjectVersions", nil)
+	s3client.FailOn("GetObject", errors.New("connection reset"))
+	s3file, err := NewS3File(context.TODO(), testBucket, testKey, s3client)
+	if err != nil {
+		t.Fatalf("could not initialize s3file: %v", err)
+	}
+	if _, err := ioutil.ReadAll(s3file); err == nil {
+		t.Fatalf("expected download errors to be returned")
+	}
+}
+
+func TestParseSSECustomerKey(t *testing.T) {
+	raw := bytes.Repeat([]byte{' '}, 32)
+	if key, err := ParseSSECustomerKey(raw); err != nil || !bytes.Equal(key, raw) {
+		t.Fatalf("raw keys should be used as is")
+	}
+	if key, err := ParseSSECustomerKey([]byte("a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s=\n")); err != nil || string(key) != string(bytes.Repeat([]byte("k"), 32)) {
+		t.Fatalf("base64 keys should be decoded: %v", err)
+	}
+	if _, err := ParseSSECustomerKey([]byte("short")); err == nil {
+		t.Fatalf("expected an error for a short key")
+	}
+}
+
+func assertGlacierState(t *testing.T, s3file *S3File, expected GlacierState) {
+	t.Helper()
+	state, err := s3file.GetGlacierState()
 	if err != nil {
-		log.Fatalf("%v", err)
+		t.Fatalf("could not get glacier state: %v", err)
 	}
-	if finfo.Size() != *s3file.objLatestVersion.Size {
-		log.Fatalf("size does not match '%d' != '%d'", finfo.Size(), *s3file.objLatestVersion.Size)
+	if state != expected {
+		t.Fatalf("expected glacier state '%s', got '%s'", expected, state)
 	}
 }
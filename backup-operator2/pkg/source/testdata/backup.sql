@@ -0,0 +1,2525 @@
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
+INSERT INTO t VALUES (1);
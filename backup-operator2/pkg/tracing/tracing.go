@@ -0,0 +1,100 @@
+// Package tracing configures the OpenTelemetry tracing of the operator
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Tracing is disabled
+	ExporterNone = "none"
+	// Spans are written to stdout, for local testing
+	ExporterStdout = "stdout"
+	// Spans are sent to an OTLP/HTTP collector
+	ExporterOTLP = "otlp"
+
+	otlpTracesPath      = "/v1/traces"
+	serviceName         = "backup-operator"
+	instrumentationName = "github.com/nvanheuverzwijn/backup-operator"
+)
+
+// Attributes set on the spans
+const (
+	ClaimUID       = attribute.Key("backupclaim.uid")
+	ClaimNamespace = attribute.Key("backupclaim.namespace")
+	ClaimName      = attribute.Key("backupclaim.name")
+)
+
+// Tracer creates the spans of the operator. Spans are dropped until Setup
+// installs an exporter.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global tracer provider, sending the spans to exporter.
+// endpoint is the URL of the collector for ExporterOTLP, the OTEL_EXPORTER_OTLP
+// environment variables apply when it is empty. The returned function flushes
+// the pending spans and stops the exporter.
+func Setup(exporter, endpoint string) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		spanExporter, err = newOTLPExporter(endpoint)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter '%s'", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newOTLPExporter returns an exporter sending the spans to endpoint, like
+// http://otel-collector:4318. The path defaults to /v1/traces.
+func newOTLPExporter(endpoint string) (*otlptrace.Exporter, error) {
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid otlp endpoint '%s'", endpoint)
+		}
+		if u.Path == "" || u.Path == "/" {
+			u.Path = otlpTracesPath
+		}
+		opts = append(opts, otlptracehttp.WithEndpointURL(u.String()))
+	}
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
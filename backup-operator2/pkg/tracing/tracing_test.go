@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordSpans creates a Reconcile span with a failed S3File.Read child
+func recordSpans(t *testing.T, exporter sdktrace.SpanExporter) {
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer(instrumentationName)
+	ctx, parent := tracer.Start(context.Background(), "Reconcile")
+	parent.SetAttributes(ClaimUID.String("1234"))
+	_, child := tracer.Start(ctx, "S3File.Read")
+	child.SetAttributes(attribute.Int64("s3.bytes", 42))
+	End(child, errors.New("connection reset"))
+	End(parent, nil)
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("could not shutdown provider: %v", err)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var requests []*coltracepb.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		var request coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, &request)
+	}))
+	defer server.Close()
+
+	exporter, err := newOTLPExporter(server.URL)
+	if err != nil {
+		t.Fatalf("could not create exporter: %v", err)
+	}
+	recordSpans(t, exporter)
+
+	spans := map[string]*tracepb.Span{}
+	for _, request := range requests {
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				if ss.Scope.Name != instrumentationName {
+					t.Errorf("unexpected instrumentation scope '%s'", ss.Scope.Name)
+				}
+				for _, span := range ss.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+
+	parent, child := spans["Reconcile"], spans["S3File.Read"]
+	if parent == nil || child == nil {
+		t.Fatalf("expected both spans to be exported, got %v", spans)
+	}
+	if !bytes.Equal(child.ParentSpanId, parent.SpanId) || !bytes.Equal(child.TraceId, parent.TraceId) {
+		t.Errorf("S3File.Read should be a child of Reconcile")
+	}
+	if kv := parent.Attributes[0]; kv.Key != string(ClaimUID) || kv.Value.GetStringValue() != "1234" {
+		t.Errorf("unexpected attribute %v", kv)
+	}
+	if kv := child.Attributes[0]; kv.Value.GetIntValue() != 42 {
+		t.Errorf("unexpected attribute %v", kv)
+	}
+	if child.Status.Code != tracepb.Status_STATUS_CODE_ERROR || child.Status.Message != "connection reset" {
+		t.Errorf("unexpected status %v", child.Status)
+	}
+	if parent.Status.Code != tracepb.Status_STATUS_CODE_UNSET {
+		t.Errorf("unexpected status %v", parent.Status)
+	}
+}
+
+func TestOTLPExporterErrors(t *testing.T) {
+	if _, err := newOTLPExporter("localhost:4318"); err == nil {
+		t.Errorf("expected an error for an endpoint without scheme")
+	}
+
+	// Unavailable collectors are retried, bad requests are not
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	exporter, err := newOTLPExporter(server.URL)
+	if err != nil {
+		t.Fatalf("could not create exporter: %v", err)
+	}
+	provider := sdktrace.NewTracerProvider()
+	_, span := provider.Tracer(instrumentationName).Start(context.Background(), "Reconcile")
+	span.End()
+	if err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)}); err == nil {
+		t.Errorf("expected an error when the collector fails")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(ExporterNone, "")
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Errorf("tracing should be disabled without error: %v", err)
+	}
+	shutdown, err = Setup(ExporterStdout, "")
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Errorf("spans should be written to stdout without error: %v", err)
+	}
+	if _, err := Setup("jaeger", ""); err == nil {
+		t.Errorf("expected an error for an unsupported exporter")
+	}
+}